@@ -0,0 +1,197 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/pattern"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The most generations to run an object for when working out what it is
+const classifyGenerations = 1000
+
+// What an object on the board is
+type Object struct {
+	// The common name of the object, or a description of it if it doesn't have one we know of
+	Name string
+	// How the object repeats. Only meaningful if Settled is set.
+	Cycle Cycle
+	// Whether the object settles into a cycle in the time we're willing to wait
+	Settled bool
+	// The number of alive cells in the object as it was found
+	Population int
+	// The same for the object in any position or orientation, and in any phase of its cycle if it's already in one,
+	// so it's used to tell objects apart
+	Key string
+}
+
+/*
+Works out what the object made up of the given cells is when it's on its own. Objects are identified by the canonical
+patterns of their phases, so an object is recognized whatever phase it's in and however it's turned around.
+empty is an empty board to run the object on.
+*/
+func Classify(cells []common.Cell, empty common.GolBoard) Object {
+	p, _, _ := pattern.FromCells(append([]common.Cell{}, cells...))
+	obj := Object{Population: p.Population()}
+
+	board := p.Place(empty, 0, 0)
+	obj.Cycle, obj.Settled = FindCycle(board, classifyGenerations, true)
+	if !obj.Settled {
+		canonical, _ := p.Canonical()
+		obj.Key = canonical.Key()
+		obj.Name = fmt.Sprintf("unsettled pattern with %d cells (%08x)", obj.Population, uint32(canonical.Hash()))
+		return obj
+	}
+
+	phase := phaseKey(board.StepN(obj.Cycle.Start), obj.Cycle.Period)
+	if obj.Cycle.Start > 0 {
+		// Objects that aren't in their cycle yet, including ones that die, are only the same as objects in the same
+		// phase, not whatever they turn into
+		canonical, _ := p.Canonical()
+		obj.Key = canonical.Key()
+	} else {
+		obj.Key = phase.Key()
+		if name, ok := knownObjects(empty)[obj.Key]; ok {
+			obj.Name = name
+			return obj
+		}
+	}
+
+	switch obj.Cycle.Kind() {
+	case "dies":
+		obj.Name = fmt.Sprintf("pattern with %d cells that dies after %d generations", obj.Population, obj.Cycle.Start)
+		return obj
+	case "still life":
+		obj.Name = fmt.Sprintf("still life with %d cells (%08x)", obj.Population, uint32(phase.Hash()))
+	default:
+		obj.Name = fmt.Sprintf("period %d %s with %d cells (%08x)", obj.Cycle.Period, obj.Cycle.Kind(), obj.Population, uint32(phase.Hash()))
+	}
+	if obj.Cycle.Start > 0 {
+		obj.Name += fmt.Sprintf(" that settles after %d generations", obj.Cycle.Start)
+	}
+	return obj
+}
+
+// Returns the first of the canonical patterns of each phase of a board that's in a cycle of the given period
+func phaseKey(board common.GolBoard, period uint64) pattern.Pattern {
+	var first pattern.Pattern
+	for i := uint64(0); i < period; i++ {
+		p, _, _ := pattern.FromBoard(board)
+		canonical, _ := p.Canonical()
+		if i == 0 || canonical.Key() < first.Key() {
+			first = canonical
+		}
+		board = board.Step()
+	}
+	return first
+}
+
+// Objects that show up all the time when patterns settle down, in one of their phases
+var commonObjects = map[string][][2]int64{
+	"block":                 {{0, 0}, {1, 0}, {0, 1}, {1, 1}},
+	"beehive":               {{1, 0}, {2, 0}, {0, 1}, {3, 1}, {1, 2}, {2, 2}},
+	"loaf":                  {{1, 0}, {2, 0}, {0, 1}, {3, 1}, {1, 2}, {3, 2}, {2, 3}},
+	"boat":                  {{0, 0}, {1, 0}, {0, 1}, {2, 1}, {1, 2}},
+	"ship":                  {{0, 0}, {1, 0}, {0, 1}, {2, 1}, {1, 2}, {2, 2}},
+	"tub":                   {{1, 0}, {0, 1}, {2, 1}, {1, 2}},
+	"pond":                  {{1, 0}, {2, 0}, {0, 1}, {3, 1}, {0, 2}, {3, 2}, {1, 3}, {2, 3}},
+	"long boat":             {{0, 0}, {1, 0}, {0, 1}, {2, 1}, {1, 2}, {3, 2}, {2, 3}},
+	"barge":                 {{1, 0}, {0, 1}, {2, 1}, {1, 2}, {3, 2}, {2, 3}},
+	"mango":                 {{2, 0}, {3, 0}, {1, 1}, {4, 1}, {0, 2}, {3, 2}, {1, 3}, {2, 3}},
+	"blinker":               {{0, 0}, {0, 1}, {0, 2}},
+	"toad":                  {{0, 0}, {1, 0}, {2, 0}, {1, 1}, {2, 1}, {3, 1}},
+	"beacon":                {{0, 0}, {1, 0}, {0, 1}, {1, 1}, {2, 2}, {3, 2}, {2, 3}, {3, 3}},
+	"glider":                {{0, 0}, {1, -1}, {2, -1}, {2, 0}, {2, 1}},
+	"lightweight spaceship": {{0, 0}, {3, 0}, {4, 1}, {0, 2}, {4, 2}, {1, 3}, {2, 3}, {3, 3}, {4, 3}},
+}
+
+// The names of the common objects, by the key of their phases. Filled in the first time it's needed.
+var known map[string]string
+var findKnown sync.Once
+
+func knownObjects(empty common.GolBoard) map[string]string {
+	findKnown.Do(func() {
+		known = map[string]string{}
+		for name, cells := range commonObjects {
+			board := empty
+			for _, cell := range cells {
+				board = board.AddCell(cell[0], cell[1])
+			}
+			cycle, _ := FindCycle(board, classifyGenerations, true)
+			known[phaseKey(board, cycle.Period).Key()] = name
+		}
+	})
+	return known
+}
+
+// Returns whether an object is one of the common objects that show up all the time
+func IsCommon(obj Object) bool {
+	_, ok := commonObjects[obj.Name]
+	return ok
+}
+
+// The number of times an object shows up on a board
+type CensusEntry struct {
+	Object
+	Count int
+}
+
+/*
+Splits the board up into objects, as with Components, and counts how many there are of each kind.
+The most common objects come first.
+*/
+func Census(board common.GolBoard, distance int64) []CensusEntry {
+	empty := board.Clear()
+	counts := map[string]*CensusEntry{}
+	// Objects are usually repeated many times in the same phase and orientation, so only work out what each shape is once
+	shapes := map[string]Object{}
+
+	for _, cells := range Components(board, distance) {
+		p, _, _ := pattern.FromCells(cells)
+		obj, ok := shapes[p.Key()]
+		if !ok {
+			obj = Classify(p.Cells, empty)
+			shapes[p.Key()] = obj
+		}
+
+		if entry, ok := counts[obj.Key]; ok {
+			entry.Count++
+		} else {
+			counts[obj.Key] = &CensusEntry{obj, 1}
+		}
+	}
+
+	entries := make([]CensusEntry, 0, len(counts))
+	for _, entry := range counts {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// Returns the lines of a table showing a census
+func CensusTable(entries []CensusEntry) []string {
+	if len(entries) == 0 {
+		return []string{"The board is empty"}
+	}
+
+	lines := []string{fmt.Sprintf("%6s  %-12s  %6s  %s", "COUNT", "KIND", "PERIOD", "OBJECT")}
+	total := 0
+	for _, entry := range entries {
+		kind, period := "unsettled", "-"
+		if entry.Settled {
+			kind, period = entry.Cycle.Kind(), fmt.Sprint(entry.Cycle.Period)
+		}
+		lines = append(lines, fmt.Sprintf("%6d  %-12s  %6s  %s", entry.Count, kind, period, entry.Name))
+		total += entry.Count
+	}
+	lines = append(lines, strings.Repeat("-", 40), fmt.Sprintf("%6d  objects", total))
+	return lines
+}
@@ -0,0 +1,118 @@
+package analysis_test
+
+import (
+	. "github.com/mitchellgordon95/ConwaysGOL/analysis"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// Returns the cells moved by (dx, dy)
+func moved(cells [][]int64, dx, dy int64) [][]int64 {
+	out := make([][]int64, len(cells))
+	for i, cell := range cells {
+		out[i] = []int64{cell[0] + dx, cell[1] + dy}
+	}
+	return out
+}
+
+var (
+	block   = [][]int64{{0, 0}, {0, 1}, {1, 0}, {1, 1}}
+	blinker = [][]int64{{0, 0}, {0, 1}, {0, 2}}
+	glider  = [][]int64{{0, 0}, {1, -1}, {2, -1}, {2, 0}, {2, 1}}
+)
+
+var _ = Describe("Components", func() {
+	It("separates objects", func() {
+		board := loadBoard(append(moved(block, 0, 0), moved(block, 10, 0)...))
+		components := Components(board, 1)
+		Expect(components).To(HaveLen(2))
+		Expect(components[0]).To(HaveLen(4))
+		Expect(components[1]).To(HaveLen(4))
+	})
+	It("connects cells that touch diagonally", func() {
+		Expect(Components(loadBoard([][]int64{{0, 0}, {1, 1}, {2, 2}}), 1)).To(HaveLen(1))
+	})
+	It("connects cells further apart with a bigger distance", func() {
+		board := loadBoard(append(moved(block, 0, 0), moved(block, 4, 0)...))
+		Expect(Components(board, 1)).To(HaveLen(2))
+		Expect(Components(board, 3)).To(HaveLen(1))
+	})
+	It("limits how far apart connected cells can be", func() {
+		board := loadBoard(append(moved(block, 0, 0), moved(block, 3+MaxDistance, 0)...))
+		Expect(Components(board, 1000000)).To(HaveLen(2))
+		Expect(Components(board, MaxDistance+1)).To(HaveLen(2))
+	})
+	It("finds nothing on an empty board", func() {
+		Expect(Components(loadBoard(nil), 1)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Census", func() {
+	count := func(entries []CensusEntry) map[string]int {
+		counts := map[string]int{}
+		for _, entry := range entries {
+			counts[entry.Name] = entry.Count
+		}
+		return counts
+	}
+
+	It("counts common objects in any phase and orientation", func() {
+		cells := append(moved(block, 0, 0), moved(block, 20, 5)...)
+		cells = append(cells, moved(blinker, -10, 0)...)
+		cells = append(cells, [][]int64{{30, 30}, {31, 30}, {32, 30}}...)
+		cells = append(cells, moved(glider, 50, 50)...)
+		board := loadBoard(cells)
+
+		entries := Census(board, 1)
+		Expect(count(entries)).To(Equal(map[string]int{"block": 2, "blinker": 2, "glider": 1}))
+		Expect(entries[0].Count).To(Equal(2))
+
+		// A glider a couple of generations later is the same glider
+		Expect(count(Census(loadBoard(moved(glider, 0, 0)).StepN(2), 1))).To(Equal(map[string]int{"glider": 1}))
+	})
+	It("describes objects it doesn't know", func() {
+		// A snake, which is a still life, but not one of the common ones
+		entries := Census(loadBoard([][]int64{{0, 1}, {1, 1}, {3, 1}, {0, 0}, {2, 0}, {3, 0}}), 1)
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Settled).To(BeTrue())
+		Expect(entries[0].Cycle.Kind()).To(Equal("still life"))
+		Expect(entries[0].Name).To(HavePrefix("still life with 6 cells"))
+	})
+
+	It("doesn't mix up objects that haven't settled with what they settle into", func() {
+		cells := append(moved(block, 0, 0), [][]int64{{10, 0}, {11, 0}, {10, 1}}...)
+		// Another pre-block, turned around
+		cells = append(cells, [][]int64{{20, 1}, {21, 1}, {21, 0}}...)
+		// A lone cell and a domino, which both die right away
+		cells = append(cells, [][]int64{{30, 0}, {40, 0}, {41, 0}}...)
+
+		entries := Census(loadBoard(cells), 1)
+		Expect(entries).To(HaveLen(4))
+		counts := count(entries)
+		Expect(counts["block"]).To(Equal(1))
+		Expect(counts["pattern with 1 cells that dies after 1 generations"]).To(Equal(1))
+		Expect(counts["pattern with 2 cells that dies after 1 generations"]).To(Equal(1))
+		for _, entry := range entries {
+			if entry.Population == 3 {
+				Expect(entry.Count).To(Equal(2))
+				Expect(entry.Name).To(HaveSuffix("that settles after 1 generations"))
+			}
+		}
+	})
+	It("makes a table", func() {
+		lines := CensusTable(Census(loadBoard(moved(block, 0, 0)), 1))
+		Expect(lines).To(HaveLen(4))
+		Expect(lines[1]).To(ContainSubstring("block"))
+	})
+})
+
+var _ = Describe("Classify", func() {
+	It("recognizes objects however they're turned", func() {
+		var cells []common.Cell
+		for _, cell := range [][]int64{{0, 0}, {-1, 1}, {-1, 2}, {0, 2}, {1, 2}} {
+			cells = append(cells, common.Cell{X: cell[0], Y: cell[1]})
+		}
+		Expect(Classify(cells, loadBoard(nil)).Name).To(Equal("glider"))
+	})
+})
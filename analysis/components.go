@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+)
+
+// The furthest apart cells can be and still be connected. Every cell looks at all the cells this far around it, so
+// larger distances would be slow, and they'd merge objects that have nothing to do with each other anyway.
+const MaxDistance = 8
+
+/*
+Splits the alive cells on the board into connected groups. Two cells are connected when they're at most distance
+apart both horizontally and vertically, so a distance of 1 connects cells that touch, including diagonally.
+Larger distances keep objects that are made of separate pieces together, like some phases of oscillators, at the cost of
+merging objects that are close to each other. Distances over MaxDistance are treated as MaxDistance.
+*/
+func Components(board common.GolBoard, distance int64) [][]common.Cell {
+	if distance < 1 {
+		distance = 1
+	}
+	if distance > MaxDistance {
+		distance = MaxDistance
+	}
+
+	var cells []common.Cell
+	index := map[common.Cell]int{}
+	board.ForEachAlive(func(x, y int64) {
+		index[common.Cell{X: x, Y: y}] = len(cells)
+		cells = append(cells, common.Cell{X: x, Y: y})
+	})
+
+	// Union find over the cells, where each group is named by one of its cells
+	parent := make([]int, len(cells))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i, cell := range cells {
+		for dx := -distance; dx <= distance; dx++ {
+			for dy := -distance; dy <= distance; dy++ {
+				if j, ok := index[common.Cell{X: cell.X + dx, Y: cell.Y + dy}]; ok {
+					parent[find(i)] = find(j)
+				}
+			}
+		}
+	}
+
+	groups := map[int]int{}
+	var components [][]common.Cell
+	for i, cell := range cells {
+		root := find(i)
+		group, ok := groups[root]
+		if !ok {
+			group = len(components)
+			groups[root] = group
+			components = append(components, nil)
+		}
+		components[group] = append(components[group], cell)
+	}
+	return components
+}
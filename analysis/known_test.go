@@ -0,0 +1,27 @@
+package analysis
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/hashlife"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("commonObjects", func() {
+	It("are all different objects, already in their cycles", func() {
+		keys := map[string]string{}
+		for name, cells := range commonObjects {
+			board := hashlife.NewHashLifeBoard()
+			for _, cell := range cells {
+				board = board.AddCell(cell[0], cell[1])
+			}
+
+			cycle, ok := FindCycle(board, 100, true)
+			Expect(ok).To(BeTrue(), name)
+			Expect(cycle.Start).To(BeZero(), name)
+
+			key := phaseKey(board, cycle.Period).Key()
+			Expect(keys).NotTo(HaveKey(key), name)
+			keys[key] = name
+		}
+	})
+})
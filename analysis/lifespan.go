@@ -0,0 +1,204 @@
+package analysis
+
+import (
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/pattern"
+)
+
+// Objects bigger than this aren't checked to see whether they're spaceships flying away
+const maxShipCells = 64
+
+// Objects that don't turn out to be spaceships within this many generations aren't counted as escaping
+const maxShipPeriod = 32
+
+// How far a spaceship has to be from everything else before it counts as having escaped
+const escapeMargin = 8
+
+// A spaceship that flew away from a pattern
+type Escape struct {
+	// What kind of spaceship it was
+	Name string
+	// The generation it was found to be flying away, and removed
+	Generation uint64
+	// Where the lower left corner of its bounding box was then
+	X, Y int64
+	// How far it moves every period
+	DX, DY int64
+	Period uint64
+	// The number of alive cells in it
+	Population int
+}
+
+// How long a pattern takes to settle down
+type Lifespan struct {
+	// The generation the pattern stops changing, apart from spaceships flying away
+	Generation uint64
+	// The period of what's left
+	Period uint64
+	// The number of alive cells left when the pattern settles, not counting the spaceships that escaped
+	Population uint64
+	// The spaceships that flew away, in the order they were found
+	Escapes []Escape
+}
+
+func (l Lifespan) String() string {
+	s := fmt.Sprintf("Settles at generation %d into %d cells with period %d", l.Generation, l.Population, l.Period)
+	if len(l.Escapes) > 0 {
+		s += fmt.Sprintf(", and sends out %d spaceships (%d cells in all)", len(l.Escapes), l.TotalPopulation())
+	}
+	return s
+}
+
+// Returns the final population including the spaceships that escaped
+func (l Lifespan) TotalPopulation() uint64 {
+	total := l.Population
+	for _, escape := range l.Escapes {
+		total += uint64(escape.Population)
+	}
+	return total
+}
+
+/*
+Runs the board until it settles down, for at most the given number of generations, and returns when it settled.
+Spaceships are taken off the board once they're far enough away from everything else and heading away from it, so that a
+pattern which keeps sending out gliders still counts as settled. The rest of the board is settled once it repeats.
+Returns false if the board doesn't settle in that many generations.
+*/
+func FindLifespan(board common.GolBoard, generations uint64) (Lifespan, bool) {
+	var lifespan Lifespan
+	empty := board.Clear()
+	states := map[common.GolBoard]uint64{}
+	// What each small object is, by its pattern, so each one is only run once. Objects that aren't spaceships are nil.
+	objects := map[string]*Object{}
+
+	for gen := uint64(0); gen <= generations; gen++ {
+		var escapes []Escape
+		board, escapes = removeEscapes(board, empty, gen, objects)
+		lifespan.Escapes = append(lifespan.Escapes, escapes...)
+
+		if before, ok := states[board]; ok {
+			lifespan.Generation = before
+			lifespan.Period = gen - before
+			lifespan.Population = board.Population()
+			return lifespan, true
+		}
+		states[board] = gen
+
+		board = board.Step()
+	}
+
+	return lifespan, false
+}
+
+// The bounding box of a group of cells, with inclusive bounds
+type box struct {
+	min_x, min_y, max_x, max_y int64
+}
+
+func boxOf(cells []common.Cell) box {
+	b := box{cells[0].X, cells[0].Y, cells[0].X, cells[0].Y}
+	for _, cell := range cells {
+		b = b.union(box{cell.X, cell.Y, cell.X, cell.Y})
+	}
+	return b
+}
+
+func (b box) union(other box) box {
+	if other.min_x < b.min_x {
+		b.min_x = other.min_x
+	}
+	if other.min_y < b.min_y {
+		b.min_y = other.min_y
+	}
+	if other.max_x > b.max_x {
+		b.max_x = other.max_x
+	}
+	if other.max_y > b.max_y {
+		b.max_y = other.max_y
+	}
+	return b
+}
+
+// Returns whether something in the box moving (dx, dy) every period is heading away from everything in the rest,
+// and is already far enough away that nothing there can catch up with it
+func (b box) escaping(rest box, dx, dy int64) bool {
+	return dx > 0 && b.min_x > rest.max_x+escapeMargin ||
+		dx < 0 && b.max_x < rest.min_x-escapeMargin ||
+		dy > 0 && b.min_y > rest.max_y+escapeMargin ||
+		dy < 0 && b.max_y < rest.min_y-escapeMargin
+}
+
+// Takes the spaceships that are flying away off the board, and returns what's left
+func removeEscapes(board, empty common.GolBoard, gen uint64, objects map[string]*Object) (common.GolBoard, []Escape) {
+	if board.Population() == 0 {
+		return board, nil
+	}
+
+	components := Components(board, 2)
+	boxes := make([]box, len(components))
+	for i, cells := range components {
+		boxes[i] = boxOf(cells)
+	}
+
+	var escapes []Escape
+	for i, cells := range components {
+		if len(cells) > maxShipCells {
+			continue
+		}
+
+		var rest box
+		found := false
+		for j := range components {
+			if j == i {
+				continue
+			}
+			if !found {
+				rest, found = boxes[j], true
+			} else {
+				rest = rest.union(boxes[j])
+			}
+		}
+
+		// Spaceships are usually small, and far away from the rest, so don't bother running an object unless it's on the
+		// edge of the pattern
+		b := boxes[i]
+		if found && b.min_x <= rest.max_x+escapeMargin && b.max_x >= rest.min_x-escapeMargin &&
+			b.min_y <= rest.max_y+escapeMargin && b.max_y >= rest.min_y-escapeMargin {
+			continue
+		}
+
+		p, x, y := pattern.FromCells(append([]common.Cell{}, cells...))
+		obj, ok := objects[p.Key()]
+		if !ok {
+			obj = spaceship(p, empty)
+			objects[p.Key()] = obj
+		}
+		if obj == nil {
+			continue
+		}
+		if found && !b.escaping(rest, obj.Cycle.DX, obj.Cycle.DY) {
+			continue
+		}
+
+		for _, cell := range cells {
+			board = board.KillCell(cell.X, cell.Y)
+		}
+		escapes = append(escapes, Escape{obj.Name, gen, x, y, obj.Cycle.DX, obj.Cycle.DY, obj.Cycle.Period, obj.Population})
+	}
+
+	return board, escapes
+}
+
+// Returns what the pattern is if it's a spaceship, or nil otherwise
+func spaceship(p pattern.Pattern, empty common.GolBoard) *Object {
+	// Running a pattern for long enough to classify it is slow, so first make sure it's a spaceship
+	board := p.Place(empty, 0, 0)
+	cycle, ok := FindCycle(board, maxShipPeriod, true)
+	if !ok || cycle.Start != 0 || cycle.Kind() != "spaceship" {
+		return nil
+	}
+
+	obj := Classify(p.Cells, empty)
+	return &obj
+}
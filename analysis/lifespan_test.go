@@ -0,0 +1,52 @@
+package analysis_test
+
+import (
+	. "github.com/mitchellgordon95/ConwaysGOL/analysis"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FindLifespan", func() {
+	It("finds when the R-pentomino stabilizes", func() {
+		rpentomino := loadBoard([][]int64{{0, 0}, {1, 0}, {1, 1}, {1, -1}, {2, 1}})
+		lifespan, ok := FindLifespan(rpentomino, 2000)
+		Expect(ok).To(BeTrue())
+		Expect(lifespan.Generation).To(Equal(uint64(1103)))
+		Expect(lifespan.Period).To(Equal(uint64(2)))
+		Expect(lifespan.Escapes).To(HaveLen(6))
+		for _, escape := range lifespan.Escapes {
+			Expect(escape.Name).To(Equal("glider"))
+		}
+		Expect(lifespan.TotalPopulation()).To(Equal(uint64(116)))
+	})
+	It("finds when acorn stabilizes, though it sends out gliders at different times", func() {
+		acorn := loadBoard([][]int64{{1, 2}, {3, 1}, {0, 0}, {1, 0}, {4, 0}, {5, 0}, {6, 0}})
+		lifespan, ok := FindLifespan(acorn, 6000)
+		Expect(ok).To(BeTrue())
+		Expect(lifespan.Generation).To(Equal(uint64(5206)))
+		Expect(lifespan.Escapes).To(HaveLen(13))
+		for _, escape := range lifespan.Escapes {
+			Expect(escape.Name).To(Equal("glider"))
+		}
+		Expect(lifespan.TotalPopulation()).To(Equal(uint64(633)))
+	})
+	It("sends a glider away right away", func() {
+		lifespan, ok := FindLifespan(loadBoard(glider), 100)
+		Expect(ok).To(BeTrue())
+		Expect(lifespan.Generation).To(Equal(uint64(0)))
+		Expect(lifespan.Population).To(Equal(uint64(0)))
+		Expect(lifespan.Escapes).To(HaveLen(1))
+	})
+	It("finds oscillators", func() {
+		lifespan, ok := FindLifespan(loadBoard(blinker), 100)
+		Expect(ok).To(BeTrue())
+		Expect(lifespan.Generation).To(Equal(uint64(0)))
+		Expect(lifespan.Period).To(Equal(uint64(2)))
+		Expect(lifespan.Population).To(Equal(uint64(3)))
+	})
+	It("gives up on boards that don't settle in time", func() {
+		rpentomino := loadBoard([][]int64{{0, 0}, {1, 0}, {1, 1}, {1, -1}, {2, 1}})
+		_, ok := FindLifespan(rpentomino, 1000)
+		Expect(ok).To(BeFalse())
+	})
+})
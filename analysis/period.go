@@ -0,0 +1,92 @@
+/*
+analysis answers questions about how patterns on a board behave over time
+*/
+package analysis
+
+import (
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/pattern"
+)
+
+// How a pattern settles into a cycle
+type Cycle struct {
+	// The number of generations it takes the pattern to repeat
+	Period uint64
+	// How far the pattern moves each period. Only spaceships move.
+	DX, DY int64
+	// The first generation of the cycle, counting the board it started from as generation 0
+	Start uint64
+	// Whether the pattern died out, which counts as a cycle of period 1
+	Dead bool
+}
+
+// Returns what kind of pattern repeats this way
+func (c Cycle) Kind() string {
+	switch {
+	case c.Dead:
+		return "dies"
+	case c.DX != 0 || c.DY != 0:
+		return "spaceship"
+	case c.Period == 1:
+		return "still life"
+	}
+	return "oscillator"
+}
+
+func (c Cycle) String() string {
+	switch c.Kind() {
+	case "dies":
+		return fmt.Sprintf("Dies out at generation %d", c.Start)
+	case "spaceship":
+		return fmt.Sprintf("Spaceship with period %d, moving (%d,%d) every period, starting at generation %d", c.Period, c.DX, c.DY, c.Start)
+	case "still life":
+		return fmt.Sprintf("Still life, starting at generation %d", c.Start)
+	}
+	return fmt.Sprintf("Oscillator with period %d, starting at generation %d", c.Period, c.Start)
+}
+
+// A board state seen while looking for a cycle
+type seen struct {
+	generation uint64
+	// Where the board was shifted from, when looking for cycles up to translation
+	x, y int64
+}
+
+/*
+Steps the board until it gets back to a state it was in before, for at most the given number of generations.
+Boards share the canonical nodes of the quadtree, so two boards in the same state are equal, which makes them
+cheap to look up. If moving is set, states that are the same up to translation count as repeats too, which finds
+spaceships, at the cost of turning each state into a pattern.
+Returns false if the board never repeats in that many generations.
+*/
+func FindCycle(board common.GolBoard, generations uint64, moving bool) (Cycle, bool) {
+	// Boards, or pattern keys when looking for moving patterns
+	states := map[interface{}]seen{}
+
+	for gen := uint64(0); gen <= generations; gen++ {
+		var state interface{} = board
+		var x, y int64
+		if moving {
+			var p pattern.Pattern
+			p, x, y = pattern.FromBoard(board)
+			state = p.Key()
+		}
+
+		if before, ok := states[state]; ok {
+			_, _, _, _, alive := board.BoundingBox()
+			return Cycle{
+				Period: gen - before.generation,
+				DX:     x - before.x,
+				DY:     y - before.y,
+				Start:  before.generation,
+				Dead:   !alive,
+			}, true
+		}
+		states[state] = seen{gen, x, y}
+
+		board = board.Step()
+	}
+
+	return Cycle{}, false
+}
@@ -0,0 +1,64 @@
+package analysis_test
+
+import (
+	. "github.com/mitchellgordon95/ConwaysGOL/analysis"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/hashlife"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FindCycle", func() {
+	It("finds still lifes", func() {
+		block := loadBoard([][]int64{{0, 0}, {0, 1}, {1, 0}, {1, 1}})
+		cycle, ok := FindCycle(block, 10, false)
+		Expect(ok).To(BeTrue())
+		Expect(cycle).To(Equal(Cycle{Period: 1}))
+		Expect(cycle.Kind()).To(Equal("still life"))
+	})
+	It("finds oscillators", func() {
+		blinker := loadBoard([][]int64{{0, 0}, {0, 1}, {0, 2}})
+		cycle, ok := FindCycle(blinker, 10, false)
+		Expect(ok).To(BeTrue())
+		Expect(cycle).To(Equal(Cycle{Period: 2}))
+		Expect(cycle.Kind()).To(Equal("oscillator"))
+	})
+	It("finds when a pattern settles down", func() {
+		// Three cells in an L turn into a block
+		cycle, ok := FindCycle(loadBoard([][]int64{{0, 0}, {0, 1}, {1, 0}}), 10, false)
+		Expect(ok).To(BeTrue())
+		Expect(cycle).To(Equal(Cycle{Period: 1, Start: 1}))
+	})
+	It("finds patterns that die out", func() {
+		cycle, ok := FindCycle(loadBoard([][]int64{{0, 0}, {5, 5}}), 10, false)
+		Expect(ok).To(BeTrue())
+		Expect(cycle).To(Equal(Cycle{Period: 1, Start: 1, Dead: true}))
+		Expect(cycle.Kind()).To(Equal("dies"))
+	})
+
+	Context("with a glider", func() {
+		glider := loadBoard([][]int64{{0, 0}, {1, -1}, {2, -1}, {2, 0}, {2, 1}})
+
+		It("only finds it when looking for moving patterns", func() {
+			_, ok := FindCycle(glider, 40, false)
+			Expect(ok).To(BeFalse())
+
+			cycle, ok := FindCycle(glider, 40, true)
+			Expect(ok).To(BeTrue())
+			Expect(cycle).To(Equal(Cycle{Period: 4, DX: 1, DY: -1}))
+			Expect(cycle.Kind()).To(Equal("spaceship"))
+		})
+		It("gives up after the number of generations it's given", func() {
+			_, ok := FindCycle(glider, 3, true)
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
+
+func loadBoard(alive [][]int64) common.GolBoard {
+	board := hashlife.NewHashLifeBoard()
+	for _, cell := range alive {
+		board = board.AddCell(cell[0], cell[1])
+	}
+	return board
+}
@@ -0,0 +1,46 @@
+package analysis
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+)
+
+// The number of generations between checks for whether a board has settled down. It's a multiple of the periods of all
+// the common oscillators (including the period 8 galaxy and the period 15 pentadecathlon), so a settled board is in the
+// same phase at every check.
+const settleInterval = 120
+
+/*
+Runs the board until it settles down into objects that don't interact any more, for at most the given number of generations.
+This is a quick check meant for running lots of random soups, which uses hashlife to jump between checks. At each check,
+spaceships flying away are left out, as with FindLifespan, and the board has settled once the rest of it is exactly the
+same as at the check before. Returns the settled board, spaceships and all, and the generation it was found at, or false
+if it didn't settle in time.
+*/
+func Settle(board common.GolBoard, generations uint64) (common.GolBoard, uint64, bool) {
+	empty := board.Clear()
+	objects := map[string]*Object{}
+	last := withoutEscapes(board, empty, objects)
+	for gen := uint64(0); gen+settleInterval <= generations; {
+		board = board.StepN(settleInterval)
+		gen += settleInterval
+
+		current := withoutEscapes(board, empty, objects)
+		if current == last {
+			return board, gen, true
+		}
+		last = current
+	}
+	return board, generations, false
+}
+
+// Takes every spaceship that's flying away off the board. Spaceships only count as flying away once they're past
+// everything else, including other spaceships, so the ones furthest out have to be taken off before the ones behind them.
+func withoutEscapes(board, empty common.GolBoard, objects map[string]*Object) common.GolBoard {
+	for {
+		rest, escapes := removeEscapes(board, empty, 0, objects)
+		if len(escapes) == 0 {
+			return rest
+		}
+		board = rest
+	}
+}
@@ -0,0 +1,56 @@
+package analysis_test
+
+import (
+	. "github.com/mitchellgordon95/ConwaysGOL/analysis"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Settle", func() {
+	It("runs a board until it settles", func() {
+		rpentomino := loadBoard([][]int64{{0, 0}, {1, 0}, {1, 1}, {1, -1}, {2, 1}})
+		settled, gen, ok := Settle(rpentomino, 5000)
+		Expect(ok).To(BeTrue())
+		Expect(gen).To(BeNumerically(">=", 1103))
+		Expect(settled.Population()).To(Equal(uint64(116)))
+	})
+	It("gives up on boards that don't settle in time", func() {
+		rpentomino := loadBoard([][]int64{{0, 0}, {1, 0}, {1, 1}, {1, -1}, {2, 1}})
+		_, _, ok := Settle(rpentomino, 600)
+		Expect(ok).To(BeFalse())
+	})
+	It("settles still lifes right away", func() {
+		_, gen, ok := Settle(loadBoard(block), 1000)
+		Expect(ok).To(BeTrue())
+		Expect(gen).To(Equal(uint64(120)))
+	})
+	It("settles oscillators whatever their phase at each check", func() {
+		galaxy := picture("OOOOOO.OO", "OOOOOO.OO", ".......OO", "OO.....OO", "OO.....OO", "OO.....OO", "OO.......", "OO.OOOOOO", "OO.OOOOOO")
+		pentadecathlon := picture("..O....O..", "OO.OOOO.OO", "..O....O..")
+		for _, cells := range [][][]int64{galaxy, pentadecathlon, append(moved(galaxy, 0, 0), moved(pentadecathlon, 30, 0)...)} {
+			_, gen, ok := Settle(loadBoard(cells), 1000)
+			Expect(ok).To(BeTrue())
+			Expect(gen).To(Equal(uint64(120)))
+		}
+	})
+	It("doesn't settle while a glider is on its way to something", func() {
+		// The glider hits the block a couple of hundred generations in
+		cells := append(moved(glider, 0, 0), moved(block, 60, -60)...)
+		_, gen, ok := Settle(loadBoard(cells), 5000)
+		Expect(ok).To(BeTrue())
+		Expect(gen).To(BeNumerically(">", 240))
+	})
+})
+
+// Returns the cells in rows of a picture, where O is alive, with the top row first
+func picture(rows ...string) [][]int64 {
+	var cells [][]int64
+	for y, row := range rows {
+		for x, c := range row {
+			if c == 'O' {
+				cells = append(cells, []int64{int64(x), int64(len(rows) - 1 - y)})
+			}
+		}
+	}
+	return cells
+}
@@ -0,0 +1,123 @@
+package analysis
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+)
+
+// How far from the given cell to look for an object to track
+const trackRadius = 8
+
+// How far apart cells can be and still be part of the same tracked object
+const trackDistance = 2
+
+/*
+A Tracker follows one object as the board runs, like a spaceship, by looking for it near where it should be each time it's
+updated. Objects are told apart by splitting the nearby cells into components, so two objects that touch get merged.
+*/
+type Tracker struct {
+	// The middle of the bounding box of the object, and the generation it was last seen at
+	X, Y       float64
+	Generation uint64
+	// The cells of the object when it was last seen
+	Cells []common.Cell
+	// Where and when the object was first seen, to work out how fast it's going
+	startX, startY float64
+	start          uint64
+}
+
+// Returns a tracker for the object at or nearest to (x, y) on the board, or false if there's nothing close enough
+func NewTracker(board common.GolBoard, x, y int64, generation uint64) (*Tracker, bool) {
+	cells, ok := nearestObject(board, float64(x), float64(y), trackRadius)
+	if !ok {
+		return nil, false
+	}
+	t := &Tracker{Generation: generation, start: generation}
+	t.see(cells)
+	t.startX, t.startY = t.X, t.Y
+	return t, true
+}
+
+// Finds the object on the board at the given generation. Returns false if it's gone, like when it hit something and died.
+func (t *Tracker) Update(board common.GolBoard, generation uint64) bool {
+	b := boxOf(t.Cells)
+	x, y := t.X, t.Y
+	reach := (b.max_x-b.min_x)/2 + (b.max_y-b.min_y)/2 + trackDistance + 1
+
+	if generation > t.Generation {
+		// Run the object on its own to see where it would be if nothing got in its way
+		alone := board.Clear().AddCells(t.Cells).StepN(generation - t.Generation)
+		if min_x, min_y, max_x, max_y, ok := alone.BoundingBox(); ok {
+			x, y = float64(min_x+max_x)/2, float64(min_y+max_y)/2
+			reach = (max_x-min_x)/2 + (max_y-min_y)/2 + trackDistance + 1
+		}
+	} else if generation < t.Generation {
+		// Nothing travels faster than half a cell a generation, so going back the object can't be far from here
+		reach += int64(t.Generation-generation)/2 + 1
+	}
+
+	cells, ok := nearestObject(board, x, y, reach)
+	if !ok {
+		return false
+	}
+
+	t.Generation = generation
+	t.see(cells)
+	if generation < t.start {
+		// The board went back to before the object was first seen, so start over from here
+		t.start, t.startX, t.startY = generation, t.X, t.Y
+	}
+	return true
+}
+
+// Returns how far the object has moved each generation on average since it was first seen
+func (t *Tracker) Velocity() (dx, dy float64) {
+	if t.Generation <= t.start {
+		return 0, 0
+	}
+	elapsed := float64(t.Generation - t.start)
+	return (t.X - t.startX) / elapsed, (t.Y - t.startY) / elapsed
+}
+
+func (t *Tracker) see(cells []common.Cell) {
+	b := boxOf(cells)
+	t.Cells = cells
+	t.X, t.Y = float64(b.min_x+b.max_x)/2, float64(b.min_y+b.max_y)/2
+}
+
+// Returns the cells of the object with a cell closest to (x, y), looking only at cells at most radius away
+func nearestObject(board common.GolBoard, x, y float64, radius int64) ([]common.Cell, bool) {
+	center_x, center_y := int64(x), int64(y)
+	var cells []common.Cell
+	board.ForEachAliveIn(center_x-radius, center_y-radius, center_x+radius+1, center_y+radius+1, func(x, y int64) {
+		cells = append(cells, common.Cell{X: x, Y: y})
+	})
+	if len(cells) == 0 {
+		return nil, false
+	}
+
+	var nearest []common.Cell
+	best := 0.0
+	for _, component := range Components(board.Clear().AddCells(cells), trackDistance) {
+		for _, cell := range component {
+			if d := distance(cell, x, y); nearest == nil || d < best {
+				nearest, best = component, d
+			}
+		}
+	}
+	return nearest, true
+}
+
+// Returns how far a cell is from a point, in the number of king moves it would take to get there
+func distance(cell common.Cell, x, y float64) float64 {
+	dx, dy := float64(cell.X)-x, float64(cell.Y)-y
+	if dx < 0 {
+		dx = -dx
+	}
+	if dy < 0 {
+		dy = -dy
+	}
+	if dx > dy {
+		return dx
+	}
+	return dy
+}
@@ -0,0 +1,55 @@
+package analysis_test
+
+import (
+	. "github.com/mitchellgordon95/ConwaysGOL/analysis"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tracker", func() {
+	It("follows a glider", func() {
+		board := loadBoard(append(moved(glider, 0, 0), moved(glider, 20, 20)...))
+		tracker, ok := NewTracker(board, 1, 0, 0)
+		Expect(ok).To(BeTrue())
+		Expect(tracker.Cells).To(HaveLen(5))
+
+		for gen := uint64(1); gen <= 200; gen++ {
+			board = board.Step()
+			Expect(tracker.Update(board, gen)).To(BeTrue())
+		}
+		// A glider moves one cell diagonally every 4 generations
+		Expect(tracker.X).To(BeNumerically("~", 51, 1))
+		Expect(tracker.Y).To(BeNumerically("~", -50, 1))
+		dx, dy := tracker.Velocity()
+		Expect(dx).To(BeNumerically("~", 0.25, 0.01))
+		Expect(dy).To(BeNumerically("~", -0.25, 0.01))
+	})
+	It("finds the glider again after a jump", func() {
+		board := loadBoard(moved(glider, 0, 0))
+		tracker, _ := NewTracker(board, 0, 0, 0)
+		Expect(tracker.Update(board.StepN(1000), 1000)).To(BeTrue())
+		Expect(tracker.X).To(BeNumerically("~", 251, 1))
+	})
+	It("doesn't mistake something else nearby for the object after a jump", func() {
+		board := loadBoard(append(moved(glider, 0, 0), moved(block, -5, -20)...))
+		tracker, _ := NewTracker(board, 0, 0, 0)
+		Expect(tracker.Update(board.StepN(100), 100)).To(BeTrue())
+		Expect(tracker.Cells).To(HaveLen(5))
+		Expect(tracker.X).To(BeNumerically("~", 26, 1))
+	})
+	It("picks the nearest object", func() {
+		board := loadBoard(append(moved(block, 0, 0), moved(glider, 6, 0)...))
+		tracker, ok := NewTracker(board, 5, 0, 0)
+		Expect(ok).To(BeTrue())
+		Expect(tracker.Cells).To(HaveLen(5))
+	})
+	It("doesn't find anything on an empty part of the board", func() {
+		_, ok := NewTracker(loadBoard(moved(block, 0, 0)), 100, 100, 0)
+		Expect(ok).To(BeFalse())
+	})
+	It("loses objects that die", func() {
+		board := loadBoard([][]int64{{0, 0}, {1, 1}})
+		tracker, _ := NewTracker(board, 0, 0, 0)
+		Expect(tracker.Update(board.Step(), 1)).To(BeFalse())
+	})
+})
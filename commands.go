@@ -0,0 +1,469 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/analysis"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/display"
+	"github.com/mitchellgordon95/ConwaysGOL/files"
+	"github.com/mitchellgordon95/ConwaysGOL/hashlife"
+	"github.com/mitchellgordon95/ConwaysGOL/search"
+	"github.com/mitchellgordon95/ConwaysGOL/soup"
+	"gopkg.in/urfave/cli.v1"
+	"os"
+	"os/signal"
+	"runtime"
+)
+
+// Flags for the commands that work on a board read from a file, or made from a random soup
+var boardFlags = append([]cli.Flag{
+	cli.StringFlag{
+		Name:  "file,f",
+		Usage: "a json file to read the board from, centered at (0,0)",
+	},
+	cli.Uint64Flag{
+		Name:  "generations,n",
+		Usage: "the number of generations to run the board for first",
+	},
+}, soupFlags...)
+
+// Flags for putting a random soup on the board
+var soupFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "soup",
+		Usage: "add a random soup of the given size, like 16x16, centered at (0,0)",
+	},
+	cli.Float64Flag{
+		Name:  "density",
+		Value: 0.5,
+		Usage: "the chance of each cell in the soup being alive",
+	},
+	cli.Int64Flag{
+		Name:  "seed",
+		Usage: "the seed for the soup, to get the same soup again. Defaults to a different seed every time",
+	},
+	cli.StringFlag{
+		Name:  "symmetry",
+		Value: "C1",
+		Usage: "the symmetry of the soup: C1 (none), C2, C4, D2, D4 or D8",
+	},
+}
+
+// Reads the board described by the board flags
+func loadBoard(c *cli.Context) (common.GolBoard, error) {
+	board := hashlife.NewHashLifeBoard()
+
+	if file := c.String("file"); file != "" {
+		var err error
+		board, err = files.LoadJson(board, file, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.IsSet("soup") {
+		s, err := soupOptions(c, c.String("soup"))
+		if err != nil {
+			return nil, err
+		}
+		board, err = s.Place(board, -s.Width/2, -s.Height/2)
+		if err != nil {
+			return nil, err
+		}
+		// Print the seed, so the soup can be made again. It goes to stderr to keep it out of the output of commands.
+		fmt.Fprintln(os.Stderr, "Added a "+s.String())
+	}
+
+	return board.StepN(c.Uint64("generations")), nil
+}
+
+// Reads the soup of the given size, like 16x16, described by the soup flags
+func soupOptions(c *cli.Context, size string) (soup.Soup, error) {
+	s := soup.Soup{Density: c.Float64("density"), Seed: c.Int64("seed")}
+	if !c.IsSet("seed") {
+		s.Seed = soup.NewSeed()
+	}
+
+	if _, err := fmt.Sscanf(size, "%dx%d", &s.Width, &s.Height); err != nil {
+		return s, errors.New("The size of the soup should look like 16x16")
+	}
+
+	var err error
+	s.Symmetry, err = soup.ParseSymmetry(c.String("symmetry"))
+	return s, err
+}
+
+// Flags for the commands that draw images of the board
+var imageFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "region,r",
+		Usage: "the corners x1,y1,x2,y2 of the region to draw. Defaults to the smallest region holding every alive cell",
+	},
+	cli.StringFlag{
+		Name:  "cell",
+		Usage: "the width of a cell in pixels (default 8)",
+	},
+	cli.StringFlag{
+		Name:  "zoom",
+		Usage: "zoom out, so that each pixel shows a 2^zoom by 2^zoom block of cells",
+	},
+	cli.BoolFlag{
+		Name:  "grid",
+		Usage: "draw gridlines between the cells",
+	},
+	cli.StringFlag{
+		Name:  "alive",
+		Usage: "the color of alive cells, like #rrggbb (default black)",
+	},
+	cli.StringFlag{
+		Name:  "dead",
+		Usage: "the color of dead cells (default white)",
+	},
+	cli.StringFlag{
+		Name:  "gridcolor",
+		Usage: "the color of the gridlines (default light grey)",
+	},
+}
+
+// Reads the image options and the region to draw from the image flags
+func imageOptions(c *cli.Context, board common.GolBoard) (opts display.ImageOptions, min_x, min_y, max_x, max_y int64, err error) {
+	opts = display.DefaultImageOptions()
+	for _, name := range []string{"cell", "zoom", "alive", "dead", "gridcolor"} {
+		if c.IsSet(name) {
+			if err = opts.Set(name, c.String(name)); err != nil {
+				return
+			}
+		}
+	}
+	opts.Gridlines = c.Bool("grid")
+
+	if c.IsSet("region") {
+		min_x, min_y, max_x, max_y, err = display.ParseRegion(c.String("region"))
+		return
+	}
+
+	min_x, min_y, max_x, max_y, ok := board.BoundingBox()
+	if !ok {
+		// Draw a single dead cell rather than nothing at all
+		return opts, 0, 0, 1, 1, nil
+	}
+	return opts, min_x, min_y, max_x + 1, max_y + 1, nil
+}
+
+// Flags for the commands that record animations
+var animationFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "frames",
+		Value: 100,
+		Usage: "the number of frames to record",
+	},
+	cli.Uint64Flag{
+		Name:  "step",
+		Value: 1,
+		Usage: "the number of generations to step between frames",
+	},
+	cli.IntFlag{
+		Name:  "jump",
+		Value: -1,
+		Usage: "step 2^jump generations between frames instead, which hashlife can do in one go",
+	},
+	cli.IntFlag{
+		Name:  "delay",
+		Value: 10,
+		Usage: "the time between frames, in hundredths of a second",
+	},
+	cli.BoolFlag{
+		Name:  "track",
+		Usage: "move the region with the pattern, so moving objects stay in view",
+	},
+}
+
+// Reads the animation described by the animation flags, which draws the given region
+func animation(c *cli.Context, min_x, min_y, max_x, max_y int64) (files.Animation, error) {
+	anim := files.Animation{
+		Frames: c.Int("frames"),
+		Step:   c.Uint64("step"),
+		Delay:  c.Int("delay"),
+		MinX:   min_x,
+		MinY:   min_y,
+		MaxX:   max_x,
+		MaxY:   max_y,
+		// Unless a region is given, make room for the pattern in every frame, not just the first one
+		Fit:   !c.IsSet("region"),
+		Track: c.Bool("track"),
+	}
+
+	if jump := c.Int("jump"); jump >= 0 {
+		if jump > 63 {
+			return anim, errors.New("The jump can be at most 63")
+		}
+		anim.Step = 1 << uint(jump)
+	}
+	return anim, nil
+}
+
+// Commands that run without the interactive interface
+var commands = []cli.Command{
+	{
+		Name:  "export",
+		Usage: "save a board to a file",
+		Subcommands: []cli.Command{
+			{
+				Name:      "png",
+				Usage:     "save a png image of a board",
+				ArgsUsage: "OUTPUT",
+				Flags:     append(append([]cli.Flag{}, boardFlags...), imageFlags...),
+				Action:    exportPng,
+			},
+			{
+				Name:      "svg",
+				Usage:     "save an svg image of a board, which takes the same options as png except zoom",
+				ArgsUsage: "OUTPUT",
+				Flags: append(append(append([]cli.Flag{}, boardFlags...), imageFlags...),
+					cli.BoolFlag{
+						Name:  "labels",
+						Usage: "label coordinates along the top and left edges",
+					},
+					cli.BoolFlag{
+						Name:  "crop",
+						Usage: "crop the region to the alive cells in it",
+					},
+				),
+				Action: exportSvg,
+			},
+			{
+				Name:      "gif",
+				Usage:     "save an animated gif of a board as it runs",
+				ArgsUsage: "OUTPUT",
+				Flags:     append(append(append([]cli.Flag{}, boardFlags...), imageFlags...), animationFlags...),
+				Action:    exportAnimation,
+			},
+			{
+				Name:      "frames",
+				Usage:     "save a numbered png of each frame of a board as it runs. OUTPUT can have a printf verb for the frame number, like frame-%04d.png",
+				ArgsUsage: "OUTPUT",
+				Flags:     append(append(append([]cli.Flag{}, boardFlags...), imageFlags...), animationFlags...),
+				Action:    exportAnimation,
+			},
+		},
+	},
+	{
+		Name:  "census",
+		Usage: "count the objects on a board, like blocks, blinkers and gliders",
+		Flags: append(append([]cli.Flag{}, boardFlags...),
+			cli.Int64Flag{
+				Name:  "distance,d",
+				Value: 1,
+				Usage: "cells at most this far apart count as part of the same object",
+			},
+		),
+		Action: census,
+	},
+	{
+		Name:  "search",
+		Usage: "run lots of random soups until they settle, count the objects left over, and save the seeds of soups with rare objects",
+		Flags: append(append([]cli.Flag{}, soupFlags...),
+			cli.Uint64Flag{
+				Name:  "soups",
+				Value: 1000,
+				Usage: "the number of soups to run, counting any run before resuming",
+			},
+			cli.IntFlag{
+				Name:  "workers,w",
+				Value: runtime.NumCPU(),
+				Usage: "the number of soups to run at the same time",
+			},
+			cli.Uint64Flag{
+				Name:  "generations,n",
+				Value: 50000,
+				Usage: "the most generations to run a soup for before giving up on it settling",
+			},
+			cli.Int64Flag{
+				Name:  "distance,d",
+				Value: 1,
+				Usage: "cells at most this far apart count as part of the same object",
+			},
+			cli.StringFlag{
+				Name:  "output,o",
+				Value: "search.json",
+				Usage: "the file to save the results to",
+			},
+			cli.BoolFlag{
+				Name:  "resume",
+				Usage: "pick up a search where it left off, from the results saved in the output file",
+			},
+			cli.Uint64Flag{
+				Name:  "save-every",
+				Value: 100,
+				Usage: "the number of soups to run between saves",
+			},
+		),
+		Action: searchSoups,
+	},
+}
+
+func searchSoups(c *cli.Context) error {
+	output := c.String("output")
+
+	var results *search.Results
+	if c.Bool("resume") {
+		var err error
+		results, err = search.Load(output)
+		if err != nil {
+			return cli.NewExitError("Could not resume the search: "+err.Error(), 1)
+		}
+		fmt.Fprintf(os.Stderr, "Resuming the search after %d soups\n", results.Soups)
+	} else {
+		if _, err := os.Stat(output); err == nil {
+			return cli.NewExitError(output+" already exists. Use --resume to carry on with that search, or choose another file with --output", 1)
+		}
+
+		size := "16x16"
+		if c.IsSet("soup") {
+			size = c.String("soup")
+		}
+		s, err := soupOptions(c, size)
+		if err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+		results = search.NewResults(s)
+		fmt.Fprintf(os.Stderr, "Searching %dx%d %s soups with density %g, starting from seed %d\n", s.Width, s.Height, s.Symmetry, s.Density, s.Seed)
+	}
+
+	distance, err := distanceFlag(c)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	opts := search.Options{
+		Soup:        results.Soup,
+		Soups:       c.Uint64("soups"),
+		Workers:     c.Int("workers"),
+		Generations: c.Uint64("generations"),
+		Distance:    distance,
+		SaveEvery:   c.Uint64("save-every"),
+	}
+
+	// Stop handing out soups on Ctrl-C, and save what we have so the search can be resumed
+	stop := make(chan struct{})
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		fmt.Fprintln(os.Stderr, "Stopping after the soups being run now")
+		close(stop)
+	}()
+
+	save := func(results *search.Results) error {
+		fmt.Fprintf(os.Stderr, "Ran %d soups, %d didn't settle, %d rare finds\n", results.Soups, results.Unsettled, len(results.Finds))
+		return results.Save(output)
+	}
+	if err := search.Run(results, opts, stop, save); err != nil {
+		return cli.NewExitError("Could not save the results: "+err.Error(), 1)
+	}
+
+	for _, line := range results.Table() {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// Returns the distance cells can be apart and still count as part of the same object
+func distanceFlag(c *cli.Context) (int64, error) {
+	distance := c.Int64("distance")
+	if distance < 1 || distance > analysis.MaxDistance {
+		return 0, fmt.Errorf("The distance should be from 1 to %d", analysis.MaxDistance)
+	}
+	return distance, nil
+}
+
+func census(c *cli.Context) error {
+	board, err := loadBoard(c)
+	if err != nil {
+		return cli.NewExitError("Could not load the board: "+err.Error(), 1)
+	}
+
+	distance, err := distanceFlag(c)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	for _, line := range analysis.CensusTable(analysis.Census(board, distance)) {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func exportPng(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return cli.NewExitError("Expected the file to save the image to", 1)
+	}
+
+	board, err := loadBoard(c)
+	if err != nil {
+		return cli.NewExitError("Could not load the board: "+err.Error(), 1)
+	}
+
+	opts, min_x, min_y, max_x, max_y, err := imageOptions(c, board)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	if err := files.SavePng(board, c.Args().First(), min_x, min_y, max_x, max_y, opts); err != nil {
+		return cli.NewExitError("Could not save the image: "+err.Error(), 1)
+	}
+	return nil
+}
+
+func exportSvg(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return cli.NewExitError("Expected the file to save the image to", 1)
+	}
+
+	board, err := loadBoard(c)
+	if err != nil {
+		return cli.NewExitError("Could not load the board: "+err.Error(), 1)
+	}
+
+	opts, min_x, min_y, max_x, max_y, err := imageOptions(c, board)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	svg := files.SvgOptions{ImageOptions: opts, Labels: c.Bool("labels"), Crop: c.Bool("crop")}
+	if err := files.SaveSvg(board, c.Args().First(), min_x, min_y, max_x, max_y, svg); err != nil {
+		return cli.NewExitError("Could not save the image: "+err.Error(), 1)
+	}
+	return nil
+}
+
+// Saves an animation as a gif or a sequence of pngs, depending on which command was run
+func exportAnimation(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return cli.NewExitError("Expected the file to save the animation to", 1)
+	}
+
+	board, err := loadBoard(c)
+	if err != nil {
+		return cli.NewExitError("Could not load the board: "+err.Error(), 1)
+	}
+
+	opts, min_x, min_y, max_x, max_y, err := imageOptions(c, board)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	anim, err := animation(c, min_x, min_y, max_x, max_y)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	if c.Command.Name == "gif" {
+		err = files.SaveGif(board, c.Args().First(), anim, opts)
+	} else {
+		_, err = files.SavePngSequence(board, c.Args().First(), anim, opts)
+	}
+	if err != nil {
+		return cli.NewExitError("Could not save the animation: "+err.Error(), 1)
+	}
+	return nil
+}
@@ -13,15 +13,54 @@ type GolBoard interface {
 	// Returns a copy of the board with cell in position (x,y) alive
 	AddCell(int64, int64) GolBoard
 
+	// Returns a copy of the board with all the given cells alive. This is much faster than adding them one at a time.
+	AddCells([]Cell) GolBoard
+
 	// Returns a copy of the board with cell in position (x,y) dead
 	KillCell(int64, int64) GolBoard
 
+	// Returns a copy of the board with all the given cells dead. This is much faster than killing them one at a time.
+	KillCells([]Cell) GolBoard
+
 	// returns whether or not a cell is alive
 	IsAlive(int64, int64) bool
 
+	// Returns the number of alive cells on the board
+	Population() uint64
+
+	// Calls the function with the coordinates of every alive cell on the board
+	ForEachAlive(func(x, y int64))
+
+	// Calls the function with the coordinates of every alive cell in a chunk of the board,
+	// from min coordinates (inclusive) to max coordinates (exclusive)
+	ForEachAliveIn(min_x, min_y, max_x, max_y int64, f func(x, y int64))
+
+	// Calls the function for every block of 2^level by 2^level cells with alive cells in it that overlaps a chunk of the board,
+	// with the coordinates of the lower left cell of the block and the number of alive cells in it.
+	// Blocks are aligned to multiples of 2^level.
+	ForEachBlockIn(level uint, min_x, min_y, max_x, max_y int64, f func(x, y int64, population uint64))
+
+	// Returns the smallest rectangle containing all the alive cells, with inclusive bounds, or false if the board is empty
+	BoundingBox() (min_x, min_y, max_x, max_y int64, ok bool)
+
+	// Returns a board with the cells alive on this board or the other one
+	Union(GolBoard) GolBoard
+
+	// Returns a board with the cells alive on both this board and the other one
+	Intersect(GolBoard) GolBoard
+
+	// Returns a board with the cells alive on exactly one of this board and the other one
+	Xor(GolBoard) GolBoard
+
+	// Returns a board with the cells alive on this board but not the other one
+	Subtract(GolBoard) GolBoard
+
 	// Returns a copy of the board stepped to the next state of the simulation
 	Step() GolBoard
 
+	// Returns a copy of the board stepped forward the given number of generations
+	StepN(uint64) GolBoard
+
 	// Returns an empty board
 	Clear() GolBoard
 }
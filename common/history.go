@@ -0,0 +1,83 @@
+package common
+
+// A cell on the board
+type Cell struct {
+	X, Y int64
+}
+
+/*
+CellHistory keeps track of how long each alive cell on a board has been alive, and of the cells that died recently.
+It's kept alongside a board by calling Advance every time the board moves forward a generation, and Sync whenever the
+board is changed in some other way.
+*/
+type CellHistory struct {
+	// Number of generations each alive cell has been alive for. Cells born in the latest generation have age 0.
+	ages map[Cell]uint64
+	// Number of generations since each recently dead cell died. Cells that died in the latest generation have 0.
+	dead map[Cell]uint64
+	// How many generations to remember dead cells for
+	Trail uint64
+}
+
+// Returns a history of the given board, with all the alive cells treated as newly born
+func NewCellHistory(board GolBoard, trail uint64) *CellHistory {
+	ch := &CellHistory{ages: map[Cell]uint64{}, dead: map[Cell]uint64{}, Trail: trail}
+	board.ForEachAlive(func(x, y int64) {
+		ch.ages[Cell{x, y}] = 0
+	})
+	return ch
+}
+
+// Updates the history with the next generation of the board
+func (ch *CellHistory) Advance(next GolBoard) {
+	ages := map[Cell]uint64{}
+	next.ForEachAlive(func(x, y int64) {
+		cell := Cell{x, y}
+		if age, ok := ch.ages[cell]; ok {
+			ages[cell] = age + 1
+		} else {
+			ages[cell] = 0
+		}
+	})
+
+	// Age the cells that were already dead, forgetting them once they fall off the end of the trail
+	dead := map[Cell]uint64{}
+	for cell, since := range ch.dead {
+		if _, alive := ages[cell]; !alive && since+1 <= ch.Trail {
+			dead[cell] = since + 1
+		}
+	}
+	// Then add the ones that just died
+	for cell := range ch.ages {
+		if _, alive := ages[cell]; !alive {
+			dead[cell] = 0
+		}
+	}
+
+	ch.ages, ch.dead = ages, dead
+}
+
+// Updates the history after the board was edited without stepping it. Cells that are still alive keep their ages,
+// and cells that were added are treated as newly born.
+func (ch *CellHistory) Sync(board GolBoard) {
+	ages := map[Cell]uint64{}
+	board.ForEachAlive(func(x, y int64) {
+		cell := Cell{x, y}
+		ages[cell] = ch.ages[cell]
+		delete(ch.dead, cell)
+	})
+	ch.ages = ages
+}
+
+// Returns how many generations the cell at (x,y) has been alive for, or false if it isn't alive
+func (ch *CellHistory) Age(x, y int64) (uint64, bool) {
+	age, ok := ch.ages[Cell{x, y}]
+	return age, ok
+}
+
+// Returns how many generations ago the cell at (x,y) died, or false if it isn't on the trail of recently dead cells.
+// A cell that died in the latest generation returns 0.
+func (ch *CellHistory) DiedAgo(x, y int64) (uint64, bool) {
+	since, ok := ch.dead[Cell{x, y}]
+	return since, ok
+}
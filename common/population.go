@@ -0,0 +1,74 @@
+package common
+
+// The population of a board at some generation
+type Sample struct {
+	Generation uint64
+	Population uint64
+	// The bounding box of the alive cells, with inclusive bounds. Only meaningful if the population isn't 0.
+	MinX, MinY, MaxX, MaxY int64
+}
+
+/*
+PopulationHistory keeps the population of a board over the most recent generations, in a ring buffer so that
+long runs only keep the latest samples. Samples are added in order of generation with Record.
+*/
+type PopulationHistory struct {
+	samples []Sample
+	// Where the next sample goes, and how many samples there are
+	next, count int
+}
+
+// Returns a history that keeps at most the given number of samples
+func NewPopulationHistory(capacity int) *PopulationHistory {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &PopulationHistory{samples: make([]Sample, capacity)}
+}
+
+// Adds a sample of the board at the given generation. If the latest sample is for the same generation, or a later one
+// (because the board was rewound), the samples from then on are replaced.
+func (ph *PopulationHistory) Record(generation uint64, board GolBoard) {
+	for ph.count > 0 && ph.latest().Generation >= generation {
+		ph.next = (ph.next + len(ph.samples) - 1) % len(ph.samples)
+		ph.count--
+	}
+
+	sample := Sample{Generation: generation, Population: board.Population()}
+	sample.MinX, sample.MinY, sample.MaxX, sample.MaxY, _ = board.BoundingBox()
+
+	ph.samples[ph.next] = sample
+	ph.next = (ph.next + 1) % len(ph.samples)
+	if ph.count < len(ph.samples) {
+		ph.count++
+	}
+}
+
+func (ph *PopulationHistory) latest() Sample {
+	return ph.samples[(ph.next+len(ph.samples)-1)%len(ph.samples)]
+}
+
+// Returns the number of samples kept
+func (ph *PopulationHistory) Len() int {
+	return ph.count
+}
+
+// Returns the most samples that will be kept
+func (ph *PopulationHistory) Capacity() int {
+	return len(ph.samples)
+}
+
+// Returns the samples, oldest first
+func (ph *PopulationHistory) Samples() []Sample {
+	out := make([]Sample, ph.count)
+	start := ph.next - ph.count + len(ph.samples)
+	for i := range out {
+		out[i] = ph.samples[(start+i)%len(ph.samples)]
+	}
+	return out
+}
+
+// Forgets all the samples
+func (ph *PopulationHistory) Clear() {
+	ph.next, ph.count = 0, 0
+}
@@ -0,0 +1,182 @@
+package display
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A displayer that can record what it shows
+type Recorder interface {
+	// Starts recording to a file
+	StartRecording(filename string) error
+	// Stops recording and finishes writing the file
+	StopRecording() error
+	// Returns whether we're recording
+	Recording() bool
+}
+
+/*
+A recordingDisplayer is a text displayer that can record everything it writes, including messages, as an asciinema v2 cast.
+The recording can be played back in a terminal with "asciinema play".
+*/
+type recordingDisplayer struct {
+	// Guards everything below, so a recording can be stopped from another goroutine, like when the user hits Ctrl-C
+	sync.Mutex
+	*textDisplayer
+	cast *castWriter
+}
+
+// Returns a text displayer that can record what it shows
+func NewRecordingDisplayer(writer io.Writer) Displayer {
+	cast := &castWriter{out: writer}
+	return &recordingDisplayer{
+		textDisplayer: NewTextDisplayer(cast).(*textDisplayer),
+		cast:          cast,
+	}
+}
+
+func (rd *recordingDisplayer) Display(board common.GolBoard, min_x, min_y, max_x, max_y int64) {
+	rd.Lock()
+	defer rd.Unlock()
+	rd.textDisplayer.Display(board, min_x, min_y, max_x, max_y)
+	rd.cast.event()
+}
+
+func (rd *recordingDisplayer) ShowMessage(msg string) {
+	rd.Lock()
+	defer rd.Unlock()
+	rd.textDisplayer.ShowMessage(msg)
+	rd.cast.event()
+}
+
+func (rd *recordingDisplayer) StartRecording(filename string) error {
+	rd.Lock()
+	defer rd.Unlock()
+	if rd.cast.file != nil {
+		return errors.New("Already recording")
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	rd.cast.file = file
+	rd.cast.events = bufio.NewWriter(file)
+	rd.cast.start = time.Now()
+
+	// The first line of a cast is a header describing the terminal
+	width, height := terminalSize()
+	header := struct {
+		Version   int               `json:"version"`
+		Width     int               `json:"width"`
+		Height    int               `json:"height"`
+		Timestamp int64             `json:"timestamp"`
+		Env       map[string]string `json:"env"`
+	}{2, width, height, rd.cast.start.Unix(), map[string]string{"TERM": os.Getenv("TERM")}}
+
+	return rd.cast.writeLine(header)
+}
+
+func (rd *recordingDisplayer) StopRecording() error {
+	rd.Lock()
+	defer rd.Unlock()
+	if rd.cast.file == nil {
+		return errors.New("Not recording")
+	}
+
+	rd.cast.event()
+	err := rd.cast.events.Flush()
+	if closeErr := rd.cast.file.Close(); err == nil {
+		err = closeErr
+	}
+	rd.cast.file, rd.cast.events = nil, nil
+	return err
+}
+
+func (rd *recordingDisplayer) Recording() bool {
+	rd.Lock()
+	defer rd.Unlock()
+	return rd.cast.file != nil
+}
+
+/*
+Passes everything written to it on to another writer, and while recording, also saves it for the cast.
+A text displayer writes a frame a little bit at a time, so the output is held on to until the frame is done,
+and then saved as a single event.
+*/
+type castWriter struct {
+	out io.Writer
+	// Output that hasn't been saved as an event yet
+	pending []byte
+	// The file we're recording to, or nil if we're not recording
+	file   *os.File
+	events *bufio.Writer
+	// When the recording started. Events are timestamped relative to this.
+	start time.Time
+}
+
+func (cw *castWriter) Write(p []byte) (int, error) {
+	if cw.file != nil {
+		cw.pending = append(cw.pending, p...)
+	}
+	return cw.out.Write(p)
+}
+
+// Saves the pending output as an event in the cast
+func (cw *castWriter) event() {
+	if cw.file == nil || len(cw.pending) == 0 {
+		return
+	}
+	// An event is [seconds since the start, "o" for output, the text written]. The terminal turns each newline into
+	// a carriage return and a newline, so do the same, or the recording won't play back right.
+	elapsed := time.Since(cw.start).Seconds()
+	cw.writeLine([]interface{}{elapsed, "o", strings.Replace(string(cw.pending), "\n", "\r\n", -1)})
+	cw.pending = cw.pending[:0]
+}
+
+// Writes a value to the cast as a line of json
+func (cw *castWriter) writeLine(val interface{}) error {
+	line, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	_, err = cw.events.Write(line)
+	return err
+}
+
+// Returns the size of the terminal in characters, or the usual 80x24 if it can't be found
+func terminalSize() (width, height int) {
+	cmd := exec.Command("stty", "size")
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return 80, 24
+	}
+	defer tty.Close()
+	cmd.Stdin = tty
+
+	out, err := cmd.Output()
+	if err != nil {
+		return 80, 24
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) != 2 {
+		return 80, 24
+	}
+	height, errHeight := strconv.Atoi(fields[0])
+	width, errWidth := strconv.Atoi(fields[1])
+	if errHeight != nil || errWidth != nil || width < 1 || height < 1 {
+		return 80, 24
+	}
+	return width, height
+}
@@ -0,0 +1,110 @@
+package display
+
+import (
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+)
+
+// How a displayer colors cells
+type ColorMode int
+
+const (
+	// Don't color cells
+	NoColor ColorMode = iota
+	// Use the xterm 256 color palette
+	Color256
+	// Use 24-bit ANSI colors
+	TrueColor
+)
+
+// A displayer that can color the cells it shows by their history
+type Colorer interface {
+	// Sets how to color cells, and the history of the board to color them by. The history is kept up to date by the caller.
+	SetColors(mode ColorMode, history *common.CellHistory)
+}
+
+// Parses the name of a color mode, as used by the "color" command
+func ParseColorMode(name string) (ColorMode, error) {
+	switch name {
+	case "off":
+		return NoColor, nil
+	case "256":
+		return Color256, nil
+	case "truecolor":
+		return TrueColor, nil
+	}
+	return NoColor, fmt.Errorf("Unknown color mode %q", name)
+}
+
+type rgb struct {
+	r, g, b uint8
+}
+
+var (
+	// Color of cells born this generation
+	bornColor = rgb{0, 255, 0}
+	// Colors of cells as they get older. Ages in between the stops are interpolated.
+	ageStops = []struct {
+		age   uint64
+		color rgb
+	}{
+		{1, rgb{255, 255, 0}},
+		{8, rgb{255, 96, 0}},
+		{64, rgb{96, 64, 255}},
+	}
+	// Color of cells that died this generation
+	diedColor = rgb{255, 0, 0}
+	// Colors at the start and the end of the trail of dead cells
+	trailStart = rgb{160, 160, 160}
+	trailEnd   = rgb{48, 48, 48}
+)
+
+// Returns the color of a cell that has been alive for the given number of generations
+func ageColor(age uint64) rgb {
+	if age == 0 {
+		return bornColor
+	}
+
+	for i := 1; i < len(ageStops); i++ {
+		if age < ageStops[i].age {
+			prev, next := ageStops[i-1], ageStops[i]
+			return blend(prev.color, next.color, float64(age-prev.age)/float64(next.age-prev.age))
+		}
+	}
+
+	return ageStops[len(ageStops)-1].color
+}
+
+// Returns the color of a cell that died the given number of generations ago, with a trail of the given length
+func deadColor(since, trail uint64) rgb {
+	if since == 0 {
+		return diedColor
+	}
+	return blend(trailStart, trailEnd, float64(since-1)/float64(trail))
+}
+
+// Linearly interpolates between two colors. t should be between 0 and 1.
+func blend(from, to rgb, t float64) rgb {
+	mix := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+	}
+	return rgb{mix(from.r, to.r), mix(from.g, to.g), mix(from.b, to.b)}
+}
+
+// Returns the escape sequence that sets the foreground color in the given mode
+func (c rgb) escape(mode ColorMode) string {
+	switch mode {
+	case TrueColor:
+		return fmt.Sprintf("\033[38;2;%d;%d;%dm", c.r, c.g, c.b)
+	case Color256:
+		// Pick the closest color in the 6x6x6 color cube, which starts at 16
+		cube := func(v uint8) int {
+			return (int(v)*5 + 127) / 255
+		}
+		return fmt.Sprintf("\033[38;5;%dm", 16+36*cube(c.r)+6*cube(c.g)+cube(c.b))
+	}
+	return ""
+}
+
+// Resets all colors
+const resetColor = "\033[0m"
@@ -1,3 +1,160 @@
 package display
 
-// TODO
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A displayer that shows the board in a web browser. It's also the http.Handler that serves the page.
+type WebDisplayer interface {
+	Displayer
+	http.Handler
+	// Returns the secret the page sends back with every command in the TokenHeader header, so that other sites the
+	// user visits can't send commands of their own
+	Token() string
+}
+
+// The header the page sends its token in. Browsers won't let another site send a custom header without asking the
+// server first, which we never agree to.
+const TokenHeader = "X-Gol-Token"
+
+/*
+webDisplayer serves an HTML5 canvas page at "/", and pushes every board and message it's asked to display to the
+pages that are open using server-sent events at "/events".
+*/
+type webDisplayer struct {
+	// Guards everything below
+	sync.Mutex
+	// Channels to send events to each open page on
+	clients map[chan event]bool
+	// The last frame shown, for pages that connect later
+	lastFrame *event
+	// A random secret, made when the displayer is, that only the page we serve knows
+	token string
+}
+
+// A server-sent event
+type event struct {
+	name, data string
+}
+
+// A chunk of the board sent to the page.
+// The corner of the chunk is sent as strings since javascript numbers can't hold every int64, and the alive cells
+// are sent relative to the corner.
+type frame struct {
+	MinX, MinY    string
+	Width, Height int64
+	Cells         [][2]int64
+}
+
+// How many events to queue for a page before we start dropping them
+const clientBuffer = 64
+
+func NewWebDisplayer() (WebDisplayer, error) {
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return &webDisplayer{clients: map[chan event]bool{}, token: hex.EncodeToString(secret)}, nil
+}
+
+func (wd *webDisplayer) Token() string {
+	return wd.token
+}
+
+func (wd *webDisplayer) Display(board common.GolBoard, min_x, min_y, max_x, max_y int64) {
+	fr := frame{
+		MinX:   strconv.FormatInt(min_x, 10),
+		MinY:   strconv.FormatInt(min_y, 10),
+		Width:  max_x - min_x,
+		Height: max_y - min_y,
+		Cells:  [][2]int64{},
+	}
+	board.ForEachAliveIn(min_x, min_y, max_x, max_y, func(x, y int64) {
+		fr.Cells = append(fr.Cells, [2]int64{x - min_x, y - min_y})
+	})
+
+	data, err := json.Marshal(fr)
+	if err != nil {
+		wd.ShowMessage("Could not send the board: " + err.Error())
+		return
+	}
+
+	ev := event{"frame", string(data)}
+	wd.Lock()
+	wd.lastFrame = &ev
+	wd.Unlock()
+	wd.broadcast(ev)
+}
+
+func (wd *webDisplayer) ShowMessage(msg string) {
+	data, _ := json.Marshal(msg)
+	wd.broadcast(event{"message", string(data)})
+}
+
+// Sends an event to every open page. Pages that aren't keeping up miss the event.
+func (wd *webDisplayer) broadcast(ev event) {
+	wd.Lock()
+	defer wd.Unlock()
+	for client := range wd.clients {
+		select {
+		case client <- ev:
+		default:
+		}
+	}
+}
+
+func (wd *webDisplayer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, strings.Replace(guiPage, "{{token}}", wd.token, 1))
+	case "/events":
+		wd.serveEvents(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// Streams events to a page until it goes away
+func (wd *webDisplayer) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	client := make(chan event, clientBuffer)
+	wd.Lock()
+	wd.clients[client] = true
+	if wd.lastFrame != nil {
+		client <- *wd.lastFrame
+	}
+	wd.Unlock()
+
+	defer func() {
+		wd.Lock()
+		delete(wd.clients, client)
+		wd.Unlock()
+	}()
+
+	for {
+		select {
+		case ev := <-client:
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.name, ev.data)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
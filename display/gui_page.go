@@ -0,0 +1,273 @@
+package display
+
+// The page served by the web displayer. It draws the frames it receives on a canvas, and sends the same commands
+// the text interface understands back to the server at "/command", along with the token the server put in the page.
+const guiPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Conway's Game of Life</title>
+<style>
+	html, body { margin: 0; height: 100%; }
+	body { display: flex; font-family: sans-serif; font-size: 14px; background: #222; color: #ddd; }
+	#board { flex: 1; min-width: 0; background: #000; cursor: crosshair; }
+	#side { width: 300px; padding: 8px; display: flex; flex-direction: column; }
+	#side > * { margin-bottom: 8px; }
+	#log { flex: 1; overflow-y: auto; font-family: monospace; font-size: 12px; white-space: pre-wrap; background: #111; padding: 4px; }
+	input[type=number] { width: 5em; }
+	#cmd { width: 100%; box-sizing: border-box; }
+</style>
+</head>
+<body>
+<canvas id="board"></canvas>
+<div id="side">
+	<div><button id="step">Step</button> <button id="stepn">Step</button> <input id="steps" type="number" value="10" min="1"> generations</div>
+	<div><button id="play">Play</button> every <input id="delay" type="number" value="100" min="0"> ms</div>
+	<div><button id="zoomin">Zoom in</button> <button id="zoomout">Zoom out</button> <button id="home">Go to 0,0</button></div>
+	<div><button id="clear">Clear</button> <button id="quit">Quit</button></div>
+	<form id="cmdform"><input id="cmd" placeholder="Any text command, e.g. load patterns/glider.json"></form>
+	<div id="pos">&nbsp;</div>
+	<div>Left drag draws cells, right drag erases them, shift drag pans, and the mouse wheel zooms.</div>
+	<div id="log"></div>
+</div>
+<script>
+"use strict";
+var canvas = document.getElementById("board");
+var ctx = canvas.getContext("2d");
+var log = document.getElementById("log");
+
+// The frame being shown. minX and minY are BigInts, alive holds "x,y" offsets from the corner.
+var view = null;
+// Sent with every command, so the server knows it came from this page
+var token = "{{token}}";
+var playing = false;
+
+function send(cmd) {
+	return fetch("/command", {method: "POST", headers: {"X-Gol-Token": token}, body: cmd});
+}
+
+function addMessage(msg) {
+	log.textContent += msg + "\n";
+	if (log.textContent.length > 20000) {
+		log.textContent = log.textContent.slice(-10000);
+	}
+	log.scrollTop = log.scrollHeight;
+}
+
+// The size of a cell in pixels, and where the view starts on the canvas
+function layout() {
+	var size = Math.max(1, Math.floor(Math.min(canvas.width / view.width, canvas.height / view.height)));
+	return {
+		size: size,
+		left: Math.floor((canvas.width - size * view.width) / 2),
+		top: Math.floor((canvas.height - size * view.height) / 2)
+	};
+}
+
+function draw() {
+	ctx.fillStyle = "#000";
+	ctx.fillRect(0, 0, canvas.width, canvas.height);
+	if (view === null) {
+		return;
+	}
+
+	var l = layout();
+	ctx.fillStyle = "#111";
+	ctx.fillRect(l.left, l.top, l.size * view.width, l.size * view.height);
+
+	if (l.size >= 6) {
+		ctx.strokeStyle = "#2a2a2a";
+		ctx.beginPath();
+		for (var x = 0; x <= view.width; x++) {
+			ctx.moveTo(l.left + x * l.size + 0.5, l.top);
+			ctx.lineTo(l.left + x * l.size + 0.5, l.top + view.height * l.size);
+		}
+		for (var y = 0; y <= view.height; y++) {
+			ctx.moveTo(l.left, l.top + y * l.size + 0.5);
+			ctx.lineTo(l.left + view.width * l.size, l.top + y * l.size + 0.5);
+		}
+		ctx.stroke();
+	}
+
+	// Axes along x = 0 and y = 0, if they're in view
+	ctx.strokeStyle = "#555";
+	ctx.beginPath();
+	var axisX = -view.minX, axisY = -view.minY;
+	if (axisX >= 0n && axisX <= BigInt(view.width)) {
+		var px = l.left + Number(axisX) * l.size + 0.5;
+		ctx.moveTo(px, l.top);
+		ctx.lineTo(px, l.top + view.height * l.size);
+	}
+	if (axisY >= 0n && axisY <= BigInt(view.height)) {
+		var py = l.top + (view.height - Number(axisY)) * l.size + 0.5;
+		ctx.moveTo(l.left, py);
+		ctx.lineTo(l.left + view.width * l.size, py);
+	}
+	ctx.stroke();
+
+	ctx.fillStyle = "#6f6";
+	view.alive.forEach(function(key) {
+		var parts = key.split(",");
+		var x = Number(parts[0]), y = Number(parts[1]);
+		ctx.fillRect(l.left + x * l.size, l.top + (view.height - 1 - y) * l.size, l.size, l.size);
+	});
+}
+
+// Returns the offset from the corner of the view of the cell under the mouse, or null
+function cellAt(e) {
+	if (view === null) {
+		return null;
+	}
+	var rect = canvas.getBoundingClientRect();
+	var l = layout();
+	var x = Math.floor((e.clientX - rect.left - l.left) / l.size);
+	var y = view.height - 1 - Math.floor((e.clientY - rect.top - l.top) / l.size);
+	if (x < 0 || y < 0 || x >= view.width || y >= view.height) {
+		return null;
+	}
+	return {x: x, y: y};
+}
+
+function absolute(cell) {
+	return (view.minX + BigInt(cell.x)) + " " + (view.minY + BigInt(cell.y));
+}
+
+function center() {
+	return {x: view.minX + BigInt(Math.floor(view.width / 2)), y: view.minY + BigInt(Math.floor(view.height / 2))};
+}
+
+function zoom(factor) {
+	if (view === null) {
+		return;
+	}
+	var width = Math.max(2, Math.round(view.width * factor));
+	var height = Math.max(2, Math.round(view.height * factor));
+	send("resize " + width + " " + height);
+}
+
+// What the mouse is doing: "draw", "erase", "pan" or null
+var drag = null, dragStart = null, lastCell = null;
+
+canvas.addEventListener("contextmenu", function(e) { e.preventDefault(); });
+
+canvas.addEventListener("mousedown", function(e) {
+	var cell = cellAt(e);
+	if (cell === null) {
+		return;
+	}
+	if (e.shiftKey) {
+		drag = "pan";
+		dragStart = cell;
+		return;
+	}
+	drag = e.button === 2 ? "erase" : "draw";
+	lastCell = null;
+	paint(cell);
+});
+
+canvas.addEventListener("mousemove", function(e) {
+	var cell = cellAt(e);
+	if (cell !== null) {
+		document.getElementById("pos").textContent = "(" + absolute(cell).replace(" ", ", ") + ")";
+	}
+	if (cell !== null && (drag === "draw" || drag === "erase")) {
+		paint(cell);
+	}
+});
+
+window.addEventListener("mouseup", function(e) {
+	if (drag === "pan") {
+		var cell = cellAt(e);
+		if (cell !== null) {
+			var c = center();
+			send("center " + (c.x - BigInt(cell.x - dragStart.x)) + " " + (c.y - BigInt(cell.y - dragStart.y)));
+		}
+	}
+	drag = null;
+});
+
+// Draws or erases a cell, updating the canvas right away rather than waiting for the next frame
+function paint(cell) {
+	var key = cell.x + "," + cell.y;
+	if (key === lastCell) {
+		return;
+	}
+	lastCell = key;
+	if (drag === "draw" && !view.alive.has(key)) {
+		view.alive.add(key);
+		send("alive " + absolute(cell));
+	} else if (drag === "erase" && view.alive.has(key)) {
+		view.alive.delete(key);
+		send("kill " + absolute(cell));
+	}
+	draw();
+}
+
+canvas.addEventListener("wheel", function(e) {
+	e.preventDefault();
+	zoom(e.deltaY > 0 ? 2 : 0.5);
+});
+
+document.getElementById("step").onclick = function() { send("next"); };
+document.getElementById("stepn").onclick = function() { send("next " + document.getElementById("steps").value); };
+document.getElementById("zoomin").onclick = function() { zoom(0.5); };
+document.getElementById("zoomout").onclick = function() { zoom(2); };
+document.getElementById("home").onclick = function() { send("center 0 0"); };
+document.getElementById("clear").onclick = function() { send("clear"); };
+document.getElementById("quit").onclick = function() { playing = false; send("quit"); };
+
+document.getElementById("play").onclick = function() {
+	playing = !playing;
+	this.textContent = playing ? "Pause" : "Play";
+	var tick = function() {
+		if (!playing) {
+			return;
+		}
+		send("next").then(function() {
+			setTimeout(tick, Number(document.getElementById("delay").value));
+		}, function() {
+			playing = false;
+		});
+	};
+	tick();
+};
+
+document.getElementById("cmdform").onsubmit = function(e) {
+	e.preventDefault();
+	var input = document.getElementById("cmd");
+	addMessage("> " + input.value);
+	send(input.value);
+	input.value = "";
+};
+
+function resize() {
+	canvas.width = canvas.clientWidth;
+	canvas.height = canvas.clientHeight;
+	draw();
+}
+window.addEventListener("resize", resize);
+resize();
+
+var events = new EventSource("/events");
+events.addEventListener("frame", function(e) {
+	var fr = JSON.parse(e.data);
+	view = {
+		minX: BigInt(fr.MinX),
+		minY: BigInt(fr.MinY),
+		width: fr.Width,
+		height: fr.Height,
+		alive: new Set(fr.Cells.map(function(c) { return c[0] + "," + c[1]; }))
+	};
+	draw();
+});
+events.addEventListener("message", function(e) {
+	addMessage(JSON.parse(e.data));
+});
+events.onerror = function() {
+	addMessage("Lost the connection to the server.");
+	events.close();
+};
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,255 @@
+package display
+
+import (
+	"errors"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// How to draw the board as an image
+type ImageOptions struct {
+	// Width and height of a cell in pixels
+	CellSize int
+	// When above 0, the image is zoomed out so that each pixel shows a block of 2^Zoom by 2^Zoom cells,
+	// which is drawn alive if any of the cells in it are. CellSize and Gridlines are ignored. At most MaxZoom.
+	Zoom uint
+	// Draw lines between the cells
+	Gridlines bool
+	// Colors of alive cells, dead cells and gridlines
+	Alive, Dead, Grid color.Color
+}
+
+// The furthest an image can be zoomed out
+const MaxZoom = 63
+
+// The options used unless the user says otherwise
+func DefaultImageOptions() ImageOptions {
+	return ImageOptions{
+		CellSize: 8,
+		Alive:    color.RGBA{0, 0, 0, 255},
+		Dead:     color.RGBA{255, 255, 255, 255},
+		Grid:     color.RGBA{192, 192, 192, 255},
+	}
+}
+
+/*
+Renders a chunk of the board, from min coordinates (inclusive) to max coordinates (exclusive), as an image.
+The top row of the image is the top row of the chunk.
+When zoomed out, the chunk is grown to line up with the blocks of cells shown by each pixel.
+*/
+func RenderImage(board common.GolBoard, min_x, min_y, max_x, max_y int64, opts ImageOptions) (*image.Paletted, error) {
+	if opts.Zoom > 0 {
+		return renderZoomedOut(board, min_x, min_y, max_x, max_y, opts)
+	}
+
+	size := opts.CellSize
+	if size < 1 {
+		size = 1
+	}
+	if err := CheckImageSize(min_x, min_y, max_x, max_y, size); err != nil {
+		return nil, err
+	}
+
+	// With gridlines, each cell has a line on its left and top edges, and there's one more line at the right and bottom of the image.
+	// Cells one pixel wide don't have room for gridlines.
+	border := 0
+	if opts.Gridlines && size > 1 {
+		border = 1
+	}
+
+	img, err := newImage((max_x-min_x)*int64(size)+int64(border), (max_y-min_y)*int64(size)+int64(border), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if border > 0 {
+		for px := 0; px < img.Rect.Dx(); px++ {
+			for py := 0; py < img.Rect.Dy(); py++ {
+				if px%size == 0 || py%size == 0 {
+					img.SetColorIndex(px, py, gridIndex)
+				}
+			}
+		}
+	}
+
+	board.ForEachAliveIn(min_x, min_y, max_x, max_y, func(x, y int64) {
+		left := int(x-min_x) * size
+		top := int(max_y-1-y) * size
+		for px := left + border; px < left+size; px++ {
+			for py := top + border; py < top+size; py++ {
+				img.SetColorIndex(px, py, aliveIndex)
+			}
+		}
+	})
+
+	return img, nil
+}
+
+// Renders a chunk of the board with a pixel for every 2^Zoom by 2^Zoom block of cells
+func renderZoomedOut(board common.GolBoard, min_x, min_y, max_x, max_y int64, opts ImageOptions) (*image.Paletted, error) {
+	if max_x <= min_x || max_y <= min_y {
+		return nil, errEmpty
+	}
+
+	// Round the corners out to the edges of the blocks. Blocks are aligned to multiples of the block size.
+	shift := opts.Zoom
+	blockMinX, blockMinY := min_x>>shift, min_y>>shift
+	blockMaxX, blockMaxY := (max_x-1)>>shift, (max_y-1)>>shift
+	if err := CheckImageSize(blockMinX, blockMinY, blockMaxX+1, blockMaxY+1, 1); err != nil {
+		return nil, err
+	}
+
+	img, err := newImage(blockMaxX-blockMinX+1, blockMaxY-blockMinY+1, opts)
+	if err != nil {
+		return nil, err
+	}
+	board.ForEachBlockIn(shift, min_x, min_y, max_x, max_y, func(x, y int64, population uint64) {
+		img.SetColorIndex(int(x>>shift-blockMinX), int(blockMaxY-y>>shift), aliveIndex)
+	})
+
+	return img, nil
+}
+
+// Indexes of the colors in the palette of a rendered image
+const (
+	deadIndex = iota
+	aliveIndex
+	gridIndex
+)
+
+// The most pixels we're willing to put in an image
+const maxPixels = 1 << 26
+
+var errTooBig = errors.New("The image would be too big. Try a smaller region or zooming out")
+
+var errEmpty = errors.New("The region to draw is empty")
+
+/*
+Returns an error if a picture of a chunk of the board, from min coordinates (inclusive) to max coordinates (exclusive),
+with each cell the given number of pixels across, would have too many pixels to make. Regions can be wider than an
+int64 can hold, so the width and height are worked out unsigned.
+*/
+func CheckImageSize(min_x, min_y, max_x, max_y int64, size int) error {
+	if max_x <= min_x || max_y <= min_y {
+		return errEmpty
+	}
+	columns, rows := uint64(max_x)-uint64(min_x), uint64(max_y)-uint64(min_y)
+	if size < 1 {
+		size = 1
+	}
+	if columns > maxPixels || rows > maxPixels || size > maxPixels {
+		return errTooBig
+	}
+	width, height := columns*uint64(size), rows*uint64(size)
+	if width > maxPixels || height > maxPixels || width*height > maxPixels {
+		return errTooBig
+	}
+	return nil
+}
+
+// Returns an image filled with the dead color, using a palette made from the colors in the options
+func newImage(width, height int64, opts ImageOptions) (*image.Paletted, error) {
+	if width <= 0 || height <= 0 {
+		return nil, errEmpty
+	}
+	if width > maxPixels || height > maxPixels || width*height > maxPixels {
+		return nil, errTooBig
+	}
+
+	palette := color.Palette{opts.Dead, opts.Alive, opts.Grid}
+	return image.NewPaletted(image.Rect(0, 0, int(width), int(height)), palette), nil
+}
+
+// Returned for a region that reaches the very edge of the board, which there's no way to end a region after
+var ErrRegionEdge = errors.New("Regions can't reach coordinate " + strconv.FormatInt(math.MaxInt64, 10))
+
+// Parses a color written as hex, like #00ff00 or 00ff00
+func ParseColor(hex string) (color.RGBA, error) {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return color.RGBA{}, errors.New("Colors should look like #rrggbb")
+	}
+
+	val, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, errors.New("Colors should look like #rrggbb")
+	}
+
+	return color.RGBA{uint8(val >> 16), uint8(val >> 8), uint8(val), 255}, nil
+}
+
+/*
+Sets one of the options from its name and a value, as typed by the user:
+
+	cell=SIZE	the width of a cell in pixels
+	zoom=LEVEL	zoom out so each pixel is a block of 2^LEVEL by 2^LEVEL cells
+	grid=on|off	draw gridlines
+	alive=COLOR, dead=COLOR, gridcolor=COLOR	colors, written like #rrggbb
+*/
+func (opts *ImageOptions) Set(name, value string) error {
+	var err error
+	switch name {
+	case "cell":
+		opts.CellSize, err = strconv.Atoi(value)
+		if err != nil || opts.CellSize < 1 {
+			return errors.New("Invalid cell size")
+		}
+	case "zoom":
+		zoom, err := strconv.ParseUint(value, 10, 8)
+		if err != nil || zoom > MaxZoom {
+			return errors.New("Invalid zoom level")
+		}
+		opts.Zoom = uint(zoom)
+	case "grid":
+		if value != "on" && value != "off" {
+			return errors.New("Expected grid=on or grid=off")
+		}
+		opts.Gridlines = value == "on"
+	case "alive":
+		opts.Alive, err = ParseColor(value)
+	case "dead":
+		opts.Dead, err = ParseColor(value)
+	case "gridcolor":
+		opts.Grid, err = ParseColor(value)
+	default:
+		return errors.New("Unknown image option " + name)
+	}
+	return err
+}
+
+/*
+Parses a region of the board typed by the user as the corners "x1,y1,x2,y2", which are both included in the region.
+Returns the region from min coordinates (inclusive) to max coordinates (exclusive), like Display takes.
+*/
+func ParseRegion(corners string) (min_x, min_y, max_x, max_y int64, err error) {
+	fields := strings.Split(corners, ",")
+	if len(fields) != 4 {
+		return 0, 0, 0, 0, errors.New("Regions should look like x1,y1,x2,y2")
+	}
+
+	var vals [4]int64
+	for i, field := range fields {
+		vals[i], err = strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return 0, 0, 0, 0, errors.New("Invalid coordinate " + field)
+		}
+	}
+
+	min_x, max_x = vals[0], vals[2]
+	if min_x > max_x {
+		min_x, max_x = max_x, min_x
+	}
+	min_y, max_y = vals[1], vals[3]
+	if min_y > max_y {
+		min_y, max_y = max_y, min_y
+	}
+	// The max coordinates are exclusive, so they have to leave room for one more
+	if max_x == math.MaxInt64 || max_y == math.MaxInt64 {
+		return 0, 0, 0, 0, ErrRegionEdge
+	}
+	return min_x, min_y, max_x + 1, max_y + 1, nil
+}
@@ -0,0 +1,127 @@
+package display
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Lines and labels drawn over the board to help find your way around it
+type Overlay struct {
+	// Draw lines along the x and y axes
+	Axes bool
+	// Draw gridlines every this many cells. 0 turns gridlines off.
+	GridSpacing int64
+	// Label coordinates along the top and left edges of the view
+	Rulers bool
+}
+
+// A displayer that can draw overlays on the board
+type Overlayer interface {
+	// Returns the overlays currently drawn
+	Overlay() Overlay
+	// Sets which overlays to draw
+	SetOverlay(Overlay)
+}
+
+// A displayer that knows where it drew each cell on the screen
+type Locator interface {
+	// Returns the cell of the most recently displayed board at the given column and row of the screen (counting from 1),
+	// or false if there isn't one there
+	CellAt(col, row int) (x, y int64, ok bool)
+}
+
+// How far apart to put ruler labels when there are no gridlines to line them up with
+const defaultRulerSpacing = 10
+
+func (td *textDisplayer) Overlay() Overlay {
+	return td.overlay
+}
+
+func (td *textDisplayer) SetOverlay(overlay Overlay) {
+	td.overlay = overlay
+}
+
+/*
+Each cell is printed as one character followed by a separator, so each cell takes up two columns,
+and rows are printed from the top of the view down. Vertical lines are drawn in the separators, and
+horizontal lines are drawn as underscores (or dots for gridlines) in the separators of the row just above the line.
+*/
+func (td *textDisplayer) CellAt(col, row int) (int64, int64, bool) {
+	col -= td.margin
+	row -= td.top
+	if col < 1 || row < 1 {
+		return 0, 0, false
+	}
+
+	x, y := td.min_x+int64(col-1)/2, td.max_y-int64(row)
+	if x >= td.max_x || y < td.min_y {
+		return 0, 0, false
+	}
+	return x, y, true
+}
+
+// Returns the separator printed just left of the cell at (x,y)
+func (td *textDisplayer) separator(x, y int64) string {
+	switch {
+	case td.overlay.Axes && x == 0:
+		return "|"
+	case td.overlay.Axes && y == 0:
+		return "_"
+	case onGridline(x, td.overlay.GridSpacing):
+		return ":"
+	case onGridline(y, td.overlay.GridSpacing):
+		return "."
+	}
+	return " "
+}
+
+// Returns whether there's a gridline at the given coordinate
+func onGridline(coord, spacing int64) bool {
+	return spacing > 0 && coord%spacing == 0
+}
+
+// Returns the spacing between ruler labels
+func (td *textDisplayer) rulerSpacing() int64 {
+	if td.overlay.GridSpacing > 0 {
+		return td.overlay.GridSpacing
+	}
+	return defaultRulerSpacing
+}
+
+// Returns the width of the ruler on the left, which is wide enough for any y coordinate in view plus a space
+func (td *textDisplayer) rulerWidth(min_y, max_y int64) int {
+	width := len(strconv.FormatInt(min_y, 10))
+	if top := len(strconv.FormatInt(max_y-1, 10)); top > width {
+		width = top
+	}
+	return width + 1
+}
+
+// Returns the ruler to print before a row, which has a label if the row is on a gridline
+func (td *textDisplayer) leftRuler(y int64) string {
+	label := ""
+	if onGridline(y, td.rulerSpacing()) {
+		label = strconv.FormatInt(y, 10)
+	}
+	return strings.Repeat(" ", td.margin-len(label)-1) + label + " "
+}
+
+// Returns the ruler to print above the board, with labels starting above the cells they belong to.
+// Labels that would run into the previous one are skipped.
+func (td *textDisplayer) topRuler(min_x, max_x int64) string {
+	ruler := []byte(strings.Repeat(" ", int(2*(max_x-min_x))))
+	free := 0
+	for x := min_x; x < max_x; x++ {
+		col := int(2 * (x - min_x))
+		if !onGridline(x, td.rulerSpacing()) || col < free {
+			continue
+		}
+		label := strconv.FormatInt(x, 10)
+		if col+len(label) > len(ruler) {
+			break
+		}
+		copy(ruler[col:], label)
+		free = col + len(label) + 1
+	}
+	return strings.TrimRight(string(ruler), " ")
+}
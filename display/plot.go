@@ -0,0 +1,142 @@
+package display
+
+import (
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"strings"
+)
+
+// Characters for a bar filled up 1/8 through 8/8 of the way
+var eighths = []rune("▁▂▃▄▅▆▇█")
+
+/*
+Splits the samples into the given number of columns by generation, and returns the highest population in each column.
+Columns no sample falls in (because the board was stepped more than one generation at a time) get the population of the
+column before them.
+*/
+func columns(samples []common.Sample, width int) []uint64 {
+	first, last := samples[0].Generation, samples[len(samples)-1].Generation
+	if last-first < uint64(width) {
+		width = int(last-first) + 1
+	}
+	// The generations can be spread over more than a uint64 can multiply by the width, so work out the columns in floating point
+	span := float64(last-first) + 1
+
+	cols := make([]uint64, width)
+	filled := make([]bool, width)
+	for _, sample := range samples {
+		col := int(float64(sample.Generation-first) / span * float64(width))
+		if col >= width {
+			col = width - 1
+		}
+		if !filled[col] || sample.Population > cols[col] {
+			cols[col], filled[col] = sample.Population, true
+		}
+	}
+	for col := 1; col < width; col++ {
+		if !filled[col] {
+			cols[col] = cols[col-1]
+		}
+	}
+	return cols
+}
+
+func maxOf(values []uint64) uint64 {
+	max := uint64(0)
+	for _, value := range values {
+		if value > max {
+			max = value
+		}
+	}
+	return max
+}
+
+/*
+Returns the lines of a chart of population against generation, at most width columns wide and height rows tall,
+not counting the labels. The chart is drawn with Unicode blocks, or with just '#' if ascii is true, so it can be shown
+anywhere a message can.
+*/
+func Chart(samples []common.Sample, width, height int, ascii bool) []string {
+	if len(samples) == 0 || width < 1 || height < 1 {
+		return nil
+	}
+
+	cols := columns(samples, width)
+	max := maxOf(cols)
+	if max == 0 {
+		max = 1
+	}
+
+	labelWidth := len(fmt.Sprint(max))
+	lines := make([]string, 0, height+2)
+	for row := height - 1; row >= 0; row-- {
+		label := ""
+		if row == height-1 {
+			label = fmt.Sprint(max)
+		} else if row == 0 {
+			label = "0"
+		}
+
+		line := []rune(fmt.Sprintf("%*s |", labelWidth, label))
+		for _, value := range cols {
+			// How many eighths of this row the bar fills
+			filled := int(float64(value)/float64(max)*float64(height*8)) - row*8
+			line = append(line, bar(filled, ascii))
+		}
+		lines = append(lines, strings.TrimRight(string(line), " "))
+	}
+
+	// Then the axis along the bottom, labelled with the first and last generations
+	first := fmt.Sprint(samples[0].Generation)
+	last := fmt.Sprint(samples[len(samples)-1].Generation)
+	lines = append(lines, strings.Repeat(" ", labelWidth)+" +"+strings.Repeat("-", len(cols)))
+	axis := strings.Repeat(" ", labelWidth+2) + first
+	if gap := len(cols) - len(first) - len(last); gap > 0 {
+		axis += strings.Repeat(" ", gap) + last
+	} else if samples[0].Generation != samples[len(samples)-1].Generation {
+		axis += " " + last
+	}
+	lines = append(lines, axis)
+
+	return lines
+}
+
+// Returns the character for a piece of a bar filled up the given number of eighths of the way
+func bar(filled int, ascii bool) rune {
+	switch {
+	case filled <= 0:
+		return ' '
+	case ascii && filled >= 4:
+		return '#'
+	case ascii:
+		return ' '
+	case filled >= 8:
+		return eighths[7]
+	}
+	return eighths[filled-1]
+}
+
+// Returns a single line chart of the population, at most width characters wide, scaled from the lowest population to the highest
+func Sparkline(samples []common.Sample, width int) string {
+	if len(samples) == 0 || width < 1 {
+		return ""
+	}
+
+	cols := columns(samples, width)
+	min, max := cols[0], maxOf(cols)
+	for _, value := range cols {
+		if value < min {
+			min = value
+		}
+	}
+
+	line := make([]rune, len(cols))
+	for i, value := range cols {
+		level := 0
+		if max > min {
+			level = int(float64(value-min) / float64(max-min) * float64(len(eighths)-1))
+		}
+		line[i] = eighths[level]
+	}
+	return string(line)
+}
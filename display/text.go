@@ -4,40 +4,54 @@ import (
 	"fmt"
 	"github.com/mitchellgordon95/ConwaysGOL/common"
 	"io"
+	"strings"
 )
 
 type textDisplayer struct {
 	io.Writer
+	// How to color cells, and the history to color them by
+	colorMode ColorMode
+	history   *common.CellHistory
+	// Lines and labels to draw over the board
+	overlay Overlay
+	// The layout of the last board displayed, so we can tell which cell is where on the screen.
+	// top and margin are the number of rows and columns taken up by the rulers.
+	min_x, min_y, max_x, max_y int64
+	top, margin                int
 }
 
 func NewTextDisplayer(writer io.Writer) Displayer {
-	return &textDisplayer{writer}
+	return &textDisplayer{Writer: writer, overlay: Overlay{Axes: true}}
+}
+
+func (td *textDisplayer) SetColors(mode ColorMode, history *common.CellHistory) {
+	td.colorMode, td.history = mode, history
 }
 
 // Displays the game board in text.
 func (td *textDisplayer) Display(board common.GolBoard, min_x, min_y, max_x, max_y int64) {
-	// Clear the screen
-	fmt.Fprintf(td, "\033c")
+	td.min_x, td.min_y, td.max_x, td.max_y = min_x, min_y, max_x, max_y
+	td.top, td.margin = 0, 0
+
+	// Clear the screen and move the cursor to the top left. Note that we don't do a full terminal reset,
+	// since that would also turn off mouse reporting.
+	fmt.Fprintf(td, "\033[H\033[2J")
+
+	if td.overlay.Rulers {
+		td.top = 1
+		td.margin = td.rulerWidth(min_y, max_y)
+		fmt.Fprintln(td, strings.Repeat(" ", td.margin)+td.topRuler(min_x, max_x))
+	}
+
 	for y := max_y - 1; y >= min_y; y-- {
+		if td.overlay.Rulers {
+			fmt.Fprint(td, td.leftRuler(y))
+		}
 		for x := min_x; x < max_x; x++ {
-			val := board.IsAlive(int64(x), int64(y))
-
-			if val {
-				fmt.Fprint(td, "O")
-			} else {
-				fmt.Fprint(td, " ")
-			}
+			fmt.Fprint(td, td.cell(board, x, y))
 
 			if x < max_x-1 {
-				if x == (max_x-min_x)/2+min_x-1 {
-					// Print a line down the middle of the grid
-					fmt.Fprint(td, "|")
-				} else if y == (max_y-min_y)/2+min_y {
-					// Print a line across the middle of the grid
-					fmt.Fprint(td, "_")
-				} else {
-					fmt.Fprint(td, " ")
-				}
+				fmt.Fprint(td, td.separator(x+1, y))
 			}
 		}
 		fmt.Fprintln(td)
@@ -47,3 +61,27 @@ func (td *textDisplayer) Display(board common.GolBoard, min_x, min_y, max_x, max
 func (td *textDisplayer) ShowMessage(msg string) {
 	fmt.Fprintln(td, msg)
 }
+
+// Returns the text for a single cell, colored by its history if coloring is on
+func (td *textDisplayer) cell(board common.GolBoard, x, y int64) string {
+	alive := board.IsAlive(x, y)
+
+	if td.colorMode == NoColor || td.history == nil {
+		if alive {
+			return "O"
+		}
+		return " "
+	}
+
+	if alive {
+		age, _ := td.history.Age(x, y)
+		return ageColor(age).escape(td.colorMode) + "O" + resetColor
+	}
+	if since, ok := td.history.DiedAgo(x, y); ok {
+		if since == 0 {
+			return diedColor.escape(td.colorMode) + "x" + resetColor
+		}
+		return deadColor(since, td.history.Trail).escape(td.colorMode) + "." + resetColor
+	}
+	return " "
+}
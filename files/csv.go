@@ -0,0 +1,38 @@
+package files
+
+import (
+	"encoding/csv"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"os"
+	"strconv"
+)
+
+/*
+Saves the population history of a board as a csv file, with a header row and then a row for each sample giving the
+generation, the population, and the bounding box of the alive cells (empty if there weren't any).
+*/
+func SavePopulationCsv(filename string, samples []common.Sample) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(file)
+	w.Write([]string{"generation", "population", "min_x", "min_y", "max_x", "max_y"})
+	for _, sample := range samples {
+		row := []string{strconv.FormatUint(sample.Generation, 10), strconv.FormatUint(sample.Population, 10), "", "", "", ""}
+		if sample.Population > 0 {
+			for i, coord := range []int64{sample.MinX, sample.MinY, sample.MaxX, sample.MaxY} {
+				row[2+i] = strconv.FormatInt(coord, 10)
+			}
+		}
+		w.Write(row)
+	}
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,190 @@
+package files
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/display"
+	"image"
+	"image/gif"
+	"image/png"
+	"os"
+	"strings"
+)
+
+// How to record an animation of a board as it runs
+type Animation struct {
+	// Number of frames to record. The first frame is the board as it is now.
+	Frames int
+	// Number of generations to step the board between frames
+	Step uint64
+	// Time between frames, in hundredths of a second
+	Delay int
+	// The region to draw, from min coordinates (inclusive) to max coordinates (exclusive)
+	MinX, MinY, MaxX, MaxY int64
+	// If set, the region is ignored, and instead made just big enough to hold the pattern in every frame
+	Fit bool
+	// If set, the region is moved in every frame to be centered on the pattern, so moving objects stay in view
+	Track bool
+}
+
+// Steps the board through the animation, and draws each frame
+func RenderAnimation(board common.GolBoard, anim Animation, opts display.ImageOptions) ([]*image.Paletted, error) {
+	if anim.Frames < 1 {
+		return nil, errors.New("An animation needs at least one frame")
+	}
+
+	boards := make([]common.GolBoard, anim.Frames)
+	for i := range boards {
+		if i > 0 {
+			board = board.StepN(anim.Step)
+		}
+		boards[i] = board
+	}
+
+	if anim.Fit {
+		if err := fitAnimation(&anim, boards); err != nil {
+			return nil, err
+		}
+	}
+
+	width, height := anim.MaxX-anim.MinX, anim.MaxY-anim.MinY
+	if opts.Zoom > 0 {
+		// Each frame's region gets moved to line up with the blocks shown by each pixel, so every frame comes out the same size.
+		// Make room for the pattern wherever it ends up in its first block.
+		block := int64(1) << opts.Zoom
+		width = (width + 2*(block-1)) &^ (block - 1)
+		height = (height + 2*(block-1)) &^ (block - 1)
+	}
+
+	frames := make([]*image.Paletted, len(boards))
+	for i, board := range boards {
+		min_x, min_y := anim.MinX, anim.MinY
+		if anim.Track {
+			if box_min_x, box_min_y, box_max_x, box_max_y, ok := board.BoundingBox(); ok {
+				min_x = box_min_x + (box_max_x-box_min_x)/2 - width/2
+				min_y = box_min_y + (box_max_y-box_min_y)/2 - height/2
+			}
+		}
+		if opts.Zoom > 0 {
+			block := int64(1) << opts.Zoom
+			min_x, min_y = min_x&^(block-1), min_y&^(block-1)
+		}
+
+		frame, err := display.RenderImage(board, min_x, min_y, min_x+width, min_y+height, opts)
+		if err != nil {
+			return nil, err
+		}
+		frames[i] = frame
+	}
+
+	return frames, nil
+}
+
+// Sets the region of the animation to the smallest one that holds the pattern in every frame.
+// When tracking, that's a region as big as the biggest pattern in any of the frames.
+func fitAnimation(anim *Animation, boards []common.GolBoard) error {
+	found := false
+	var width, height int64
+	for _, board := range boards {
+		min_x, min_y, max_x, max_y, ok := board.BoundingBox()
+		if !ok {
+			continue
+		}
+
+		if max_x-min_x+1 > width {
+			width = max_x - min_x + 1
+		}
+		if max_y-min_y+1 > height {
+			height = max_y - min_y + 1
+		}
+
+		if !found {
+			anim.MinX, anim.MinY, anim.MaxX, anim.MaxY = min_x, min_y, max_x+1, max_y+1
+			found = true
+			continue
+		}
+		if min_x < anim.MinX {
+			anim.MinX = min_x
+		}
+		if min_y < anim.MinY {
+			anim.MinY = min_y
+		}
+		if max_x+1 > anim.MaxX {
+			anim.MaxX = max_x + 1
+		}
+		if max_y+1 > anim.MaxY {
+			anim.MaxY = max_y + 1
+		}
+	}
+
+	if !found {
+		return errors.New("The board is empty in every frame")
+	}
+
+	if anim.Track {
+		anim.MaxX, anim.MaxY = anim.MinX+width, anim.MinY+height
+	}
+	return nil
+}
+
+// Records an animation of the board and saves it as an animated gif that loops forever
+func SaveGif(board common.GolBoard, filename string, anim Animation, opts display.ImageOptions) error {
+	frames, err := RenderAnimation(board, anim, opts)
+	if err != nil {
+		return err
+	}
+
+	out := &gif.GIF{}
+	for _, frame := range frames {
+		out.Image = append(out.Image, frame)
+		out.Delay = append(out.Delay, anim.Delay)
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := gif.EncodeAll(file, out); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+/*
+Records an animation of the board and saves each frame as a numbered png. The filename should have a printf verb
+for the frame number in it, like "frames/glider-%04d.png". If it doesn't, the number is added before the extension.
+Returns the number of files written.
+*/
+func SavePngSequence(board common.GolBoard, filename string, anim Animation, opts display.ImageOptions) (int, error) {
+	if !strings.Contains(filename, "%") {
+		ext := ""
+		if dot := strings.LastIndex(filename, "."); dot >= 0 {
+			filename, ext = filename[:dot], filename[dot:]
+		}
+		filename += "-%04d" + ext
+	}
+
+	frames, err := RenderAnimation(board, anim, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, frame := range frames {
+		file, err := os.Create(fmt.Sprintf(filename, i))
+		if err != nil {
+			return i, err
+		}
+		if err := png.Encode(file, frame); err != nil {
+			file.Close()
+			return i, err
+		}
+		if err := file.Close(); err != nil {
+			return i, err
+		}
+	}
+
+	return len(frames), nil
+}
@@ -2,6 +2,7 @@ package files
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/mitchellgordon95/ConwaysGOL/common"
 	"io/ioutil"
 )
@@ -12,17 +13,98 @@ type JsonBoard struct {
 
 // Load a file onto the board, with respect to the given starting position
 func LoadJson(board common.GolBoard, filename string, centerX, centerY int64) (common.GolBoard, error) {
+	cells, err := ReadJson(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range cells {
+		cells[i].X += centerX
+		cells[i].Y += centerY
+	}
+
+	return board.AddCells(cells), nil
+}
+
+// Reads the alive cells in a file, relative to the position it would be loaded at
+func ReadJson(filename string) ([]common.Cell, error) {
 	file, err := ioutil.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
 
 	var jb JsonBoard
-	json.Unmarshal(file, &jb)
+	if err := json.Unmarshal(file, &jb); err != nil {
+		return nil, err
+	}
+
+	return jb.cells()
+}
 
+func (jb JsonBoard) cells() ([]common.Cell, error) {
+	cells := make([]common.Cell, 0, len(jb.AliveCells))
 	for _, cell := range jb.AliveCells {
-		board = board.AddCell(centerX+cell[0], centerY+cell[1])
+		if len(cell) != 2 {
+			return nil, errors.New("Expected each alive cell to be an x and a y")
+		}
+		cells = append(cells, common.Cell{X: cell[0], Y: cell[1]})
+	}
+	return cells, nil
+}
+
+// Saves the alive cells on the board to a file, with respect to the given starting position, in the format LoadJson reads
+func SaveJson(board common.GolBoard, filename string, centerX, centerY int64) error {
+	return writeJson(filename, jsonBoard(board, centerX, centerY))
+}
+
+func jsonBoard(board common.GolBoard, centerX, centerY int64) JsonBoard {
+	jb := JsonBoard{AliveCells: [][]int64{}}
+	board.ForEachAlive(func(x, y int64) {
+		jb.AliveCells = append(jb.AliveCells, []int64{x - centerX, y - centerY})
+	})
+	return jb
+}
+
+func writeJson(filename string, val interface{}) error {
+	out, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, out, 0644)
+}
+
+/*
+A board along with how far it's been run and how it was being viewed. The alive cells are saved the same way as a
+JsonBoard, relative to (0, 0), so a snapshot can also be loaded as a plain board.
+*/
+type JsonSnapshot struct {
+	JsonBoard
+	Generation            uint64
+	CenterX, CenterY      int64
+	ViewWidth, ViewHeight int64
+}
+
+// Saves a snapshot of the board to a file
+func SaveSnapshot(board common.GolBoard, filename string, snapshot JsonSnapshot) error {
+	snapshot.JsonBoard = jsonBoard(board, 0, 0)
+	return writeJson(filename, snapshot)
+}
+
+// Loads a snapshot from a file onto an empty board. The cells are returned on the board, rather than in the snapshot.
+func LoadSnapshot(empty common.GolBoard, filename string) (common.GolBoard, JsonSnapshot, error) {
+	var snapshot JsonSnapshot
+	file, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, snapshot, err
+	}
+	if err := json.Unmarshal(file, &snapshot); err != nil {
+		return nil, snapshot, err
 	}
 
-	return board, nil
+	cells, err := snapshot.cells()
+	if err != nil {
+		return nil, snapshot, err
+	}
+	snapshot.AliveCells = nil
+	return empty.AddCells(cells), snapshot, nil
 }
@@ -0,0 +1,27 @@
+package files
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/display"
+	"image/png"
+	"os"
+)
+
+// Draws a chunk of the board, from min coordinates (inclusive) to max coordinates (exclusive), and saves it as a png image
+func SavePng(board common.GolBoard, filename string, min_x, min_y, max_x, max_y int64, opts display.ImageOptions) error {
+	img, err := display.RenderImage(board, min_x, min_y, max_x, max_y, opts)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,214 @@
+package files
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/display"
+	"image/color"
+	"io"
+	"os"
+	"sort"
+)
+
+// How to draw the board as an svg. The cell size, gridlines and colors are taken from the image options, and zoom is ignored.
+type SvgOptions struct {
+	display.ImageOptions
+	// Label coordinates along the top and left edges
+	Labels bool
+	// Shrink the region to the smallest one that holds all of its alive cells
+	Crop bool
+}
+
+// How far apart the coordinate labels are
+const svgLabelSpacing = 10
+
+// Sets one of the options from its name and a value, as typed by the user. Besides the image options, takes
+// labels=on|off and crop=on|off.
+func (opts *SvgOptions) Set(name, value string) error {
+	switch name {
+	case "labels", "crop":
+		if value != "on" && value != "off" {
+			return errors.New("Expected " + name + "=on or " + name + "=off")
+		}
+		if name == "labels" {
+			opts.Labels = value == "on"
+		} else {
+			opts.Crop = value == "on"
+		}
+		return nil
+	}
+	return opts.ImageOptions.Set(name, value)
+}
+
+// A horizontal run of alive cells
+type run struct {
+	x     int64
+	count int64
+}
+
+/*
+Writes a chunk of the board, from min coordinates (inclusive) to max coordinates (exclusive), as an svg.
+Alive cells next to each other in a row are drawn as a single rectangle, to keep the file small.
+*/
+func WriteSvg(w io.Writer, board common.GolBoard, min_x, min_y, max_x, max_y int64, opts SvgOptions) error {
+	rows := map[int64][]int64{}
+	board.ForEachAliveIn(min_x, min_y, max_x, max_y, func(x, y int64) {
+		rows[y] = append(rows[y], x)
+	})
+
+	if opts.Crop {
+		if len(rows) == 0 {
+			return errors.New("There aren't any alive cells to crop to")
+		}
+		min_x, min_y, max_x, max_y = cellBounds(rows)
+	}
+	if min_x >= max_x || min_y >= max_y {
+		return errors.New("The region to draw is empty")
+	}
+	// Every row and column gets a gridline and maybe a label, so big regions make huge files even when they're empty
+	if err := display.CheckImageSize(min_x, min_y, max_x, max_y, opts.CellSize); err != nil {
+		return err
+	}
+
+	size := int64(opts.CellSize)
+	if size < 1 {
+		size = 1
+	}
+	width, height := (max_x-min_x)*size, (max_y-min_y)*size
+
+	// Leave room for the labels on the top and left
+	var left, top int64
+	fontSize := size
+	if fontSize < 8 {
+		fontSize = 8
+	}
+	if opts.Labels {
+		left = fontSize * int64(maxLabelLength(min_y, max_y))
+		top = fontSize * 3 / 2
+	}
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n",
+		left+width, top+height, left+width, top+height)
+	fmt.Fprintf(out, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"%s\"/>\n", left, top, width, height, hexColor(opts.Dead))
+
+	// Sort the rows so the output is the same every time
+	ys := make([]int64, 0, len(rows))
+	for y := range rows {
+		ys = append(ys, y)
+	}
+	sort.Slice(ys, func(i, j int) bool { return ys[i] > ys[j] })
+
+	fmt.Fprintf(out, "<g fill=\"%s\">\n", hexColor(opts.Alive))
+	for _, y := range ys {
+		for _, r := range runs(rows[y]) {
+			fmt.Fprintf(out, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\"/>\n",
+				left+(r.x-min_x)*size, top+(max_y-1-y)*size, r.count*size, size)
+		}
+	}
+	fmt.Fprintln(out, "</g>")
+
+	if opts.Gridlines {
+		fmt.Fprintf(out, "<g stroke=\"%s\" stroke-width=\"1\">\n", hexColor(opts.Grid))
+		for x := int64(0); x <= max_x-min_x; x++ {
+			fmt.Fprintf(out, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\"/>\n", left+x*size, top, left+x*size, top+height)
+		}
+		for y := int64(0); y <= max_y-min_y; y++ {
+			fmt.Fprintf(out, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\"/>\n", left, top+y*size, left+width, top+y*size)
+		}
+		fmt.Fprintln(out, "</g>")
+	}
+
+	if opts.Labels {
+		fmt.Fprintf(out, "<g font-family=\"monospace\" font-size=\"%d\" fill=\"%s\">\n", fontSize, hexColor(opts.Alive))
+		// Label the first row and column, and then every so often after that, leaving room for the first labels
+		for x := min_x; x < max_x; x++ {
+			if x == min_x || (x%svgLabelSpacing == 0 && x-min_x >= 3) {
+				fmt.Fprintf(out, "<text x=\"%d\" y=\"%d\">%d</text>\n", left+(x-min_x)*size, fontSize, x)
+			}
+		}
+		for y := min_y; y < max_y; y++ {
+			if y == max_y-1 || (y%svgLabelSpacing == 0 && max_y-1-y >= 2) {
+				fmt.Fprintf(out, "<text x=\"0\" y=\"%d\">%d</text>\n", top+(max_y-y)*size, y)
+			}
+		}
+		fmt.Fprintln(out, "</g>")
+	}
+
+	fmt.Fprintln(out, "</svg>")
+	return out.Flush()
+}
+
+// Saves a chunk of the board as an svg file
+func SaveSvg(board common.GolBoard, filename string, min_x, min_y, max_x, max_y int64, opts SvgOptions) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := WriteSvg(file, board, min_x, min_y, max_x, max_y, opts); err != nil {
+		// Don't leave half a file, or an empty one if nothing could be drawn
+		file.Close()
+		os.Remove(filename)
+		return err
+	}
+	return file.Close()
+}
+
+// Sorts the x coordinates of the alive cells in a row and merges them into runs
+func runs(xs []int64) []run {
+	sort.Slice(xs, func(i, j int) bool { return xs[i] < xs[j] })
+
+	var out []run
+	for _, x := range xs {
+		if len(out) > 0 && out[len(out)-1].x+out[len(out)-1].count == x {
+			out[len(out)-1].count++
+		} else {
+			out = append(out, run{x, 1})
+		}
+	}
+	return out
+}
+
+// Returns the smallest region holding all the cells in the rows, from min coordinates (inclusive) to max coordinates (exclusive)
+func cellBounds(rows map[int64][]int64) (min_x, min_y, max_x, max_y int64) {
+	first := true
+	for y, xs := range rows {
+		for _, x := range xs {
+			if first {
+				min_x, min_y, max_x, max_y = x, y, x, y
+				first = false
+			}
+			if x < min_x {
+				min_x = x
+			}
+			if x > max_x {
+				max_x = x
+			}
+		}
+		if y < min_y {
+			min_y = y
+		}
+		if y > max_y {
+			max_y = y
+		}
+	}
+	return min_x, min_y, max_x + 1, max_y + 1
+}
+
+// Returns the number of characters needed for the longest y coordinate label, plus one for a space
+func maxLabelLength(min_y, max_y int64) int {
+	longest := len(fmt.Sprint(min_y))
+	if top := len(fmt.Sprint(max_y - 1)); top > longest {
+		longest = top
+	}
+	return longest + 1
+}
+
+// Returns a color written as #rrggbb
+func hexColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
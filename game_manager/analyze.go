@@ -0,0 +1,89 @@
+package game_manager
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/analysis"
+	"strconv"
+)
+
+// The most generations to look for a cycle in, unless the user says otherwise
+const defaultAnalyzeGenerations = 10000
+
+// Looks for the cycle the board settles into, without changing the board
+func (tm *textManager) analyze(tokens []string) {
+	generations := uint64(defaultAnalyzeGenerations)
+	moving := true
+
+	for _, token := range tokens {
+		name, value, err := splitOption(token)
+		switch {
+		case err != nil:
+		case name == "max":
+			generations, err = strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				err = errors.New("Invalid number of generations")
+			}
+		case name == "moving":
+			if value != "on" && value != "off" {
+				err = errors.New("Expected moving=on or moving=off")
+			}
+			moving = value == "on"
+		default:
+			err = errors.New("Unknown option " + name)
+		}
+
+		if err != nil {
+			tm.ShowMessage(err.Error())
+			return
+		}
+	}
+
+	cycle, ok := analysis.FindCycle(tm.board, generations, moving)
+	if !ok {
+		tm.ShowMessage("The board didn't repeat in " + strconv.FormatUint(generations, 10) + " generations")
+		return
+	}
+	tm.ShowMessage(cycle.String())
+}
+
+// Shows a table of the objects on the board
+func (tm *textManager) census(tokens []string) {
+	distance := int64(1)
+	if len(tokens) > 0 {
+		var err error
+		distance, err = strconv.ParseInt(tokens[0], 10, 64)
+		if err != nil || distance < 1 || distance > analysis.MaxDistance {
+			tm.ShowMessage(fmt.Sprintf("The distance should be from 1 to %d", analysis.MaxDistance))
+			return
+		}
+	}
+
+	for _, line := range analysis.CensusTable(analysis.Census(tm.board, distance)) {
+		tm.ShowMessage(line)
+	}
+}
+
+// Finds when the board stabilizes and what spaceships fly away from it, without changing the board
+func (tm *textManager) lifespan(tokens []string) {
+	generations := uint64(defaultAnalyzeGenerations)
+	if len(tokens) > 0 {
+		var err error
+		generations, err = strconv.ParseUint(tokens[0], 10, 64)
+		if err != nil {
+			tm.ShowMessage("Invalid number of generations")
+			return
+		}
+	}
+
+	lifespan, ok := analysis.FindLifespan(tm.board, generations)
+	if !ok {
+		tm.ShowMessage("The board didn't stabilize in " + strconv.FormatUint(generations, 10) + " generations")
+		return
+	}
+	tm.ShowMessage(lifespan.String())
+	for _, escape := range lifespan.Escapes {
+		tm.ShowMessage(fmt.Sprintf("    %s leaving (%d, %d) at generation %d, moving (%d, %d) every %d generations",
+			escape.Name, escape.X, escape.Y, escape.Generation, escape.DX, escape.DY, escape.Period))
+	}
+}
@@ -0,0 +1,132 @@
+package game_manager
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/pattern"
+	"strconv"
+)
+
+// A rectangle on the board, from min coordinates (inclusive) to max coordinates (exclusive)
+type selection struct {
+	min_x, min_y, max_x, max_y int64
+}
+
+func (s selection) String() string {
+	return fmt.Sprintf("(%d,%d) to (%d,%d)", s.min_x, s.min_y, s.max_x-1, s.max_y-1)
+}
+
+// Selects the rectangle between two corners, for copy and cut
+func (tm *textManager) selectRegion(tokens []string) {
+	if len(tokens) == 0 {
+		if tm.selection == nil {
+			tm.ShowMessage("Nothing is selected")
+			return
+		}
+		tm.ShowMessage("Selected " + tm.selection.String())
+		return
+	}
+	if len(tokens) < 4 {
+		tm.ShowMessage("Not enough arguments")
+		return
+	}
+
+	x1, y1, err := parseCoordinates(tokens[0:2])
+	if err != nil {
+		tm.ShowMessage(err.Error())
+		return
+	}
+	x2, y2, err := parseCoordinates(tokens[2:4])
+	if err != nil {
+		tm.ShowMessage(err.Error())
+		return
+	}
+
+	// The corners can be given in any order, and are both part of the selection
+	if x2 < x1 {
+		x1, x2 = x2, x1
+	}
+	if y2 < y1 {
+		y1, y2 = y2, y1
+	}
+	tm.selection = &selection{x1, y1, x2 + 1, y2 + 1}
+	tm.ShowMessage("Selected " + tm.selection.String())
+}
+
+// Copies the selection to the clipboard, and if cut is true, kills the cells in it
+func (tm *textManager) copyRegion(cut bool) {
+	if tm.selection == nil {
+		tm.ShowMessage("Nothing is selected")
+		return
+	}
+
+	s := tm.selection
+	region := pattern.FromRegion(tm.board, s.min_x, s.min_y, s.max_x, s.max_y)
+	tm.clipboard = &region
+
+	msg := fmt.Sprintf("Copied %d cells in %dx%d", len(region.Cells), region.Width, region.Height)
+	if cut {
+		tm.setBoard(pattern.Clear(tm.board, s.min_x, s.min_y, s.max_x, s.max_y))
+		tm.showBoard()
+		msg = fmt.Sprintf("Cut %d cells in %dx%d", len(region.Cells), region.Width, region.Height)
+	}
+	tm.ShowMessage(msg)
+}
+
+// Pastes the clipboard with its lower left corner at (x, y), turned around and combined with the board as asked
+func (tm *textManager) paste(tokens []string) {
+	if tm.clipboard == nil {
+		tm.ShowMessage("The clipboard is empty")
+		return
+	}
+	if len(tokens) < 2 {
+		tm.ShowMessage("Not enough arguments")
+		return
+	}
+	x, y, err := parseCoordinates(tokens)
+	if err != nil {
+		tm.ShowMessage(err.Error())
+		return
+	}
+
+	orientation, mode, err := parseTransform(tokens[2:])
+	if err != nil {
+		tm.ShowMessage(err.Error())
+		return
+	}
+
+	region := tm.clipboard.Orient(orientation)
+	tm.setBoard(region.Paste(tm.board, x, y, mode))
+	tm.showBoard()
+	tm.ShowMessage(fmt.Sprintf("Pasted %d cells at (%d,%d)", len(region.Cells), x, y))
+}
+
+/*
+Parses how to turn a pattern around and how to combine it with the board: a rotation of 0, 90, 180 or 270 degrees
+counterclockwise, "flip" to mirror it left to right first, and a paste mode of or, xor or copy, in any order.
+*/
+func parseTransform(tokens []string) (pattern.Orientation, pattern.PasteMode, error) {
+	var orientation pattern.Orientation
+	mode := pattern.PasteOr
+
+	for _, token := range tokens {
+		if token == "flip" {
+			orientation.Flip = true
+			continue
+		}
+		if degrees, err := strconv.Atoi(token); err == nil {
+			if degrees%90 != 0 {
+				return orientation, mode, errors.New("Rotations have to be a multiple of 90 degrees")
+			}
+			orientation.Turns = (degrees/90%4 + 4) % 4
+			continue
+		}
+		parsed, err := pattern.ParsePasteMode(token)
+		if err != nil {
+			return orientation, mode, errors.New("Expected a rotation, flip, or a paste mode of or, xor or copy, not " + token)
+		}
+		mode = parsed
+	}
+
+	return orientation, mode, nil
+}
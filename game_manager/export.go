@@ -0,0 +1,160 @@
+package game_manager
+
+import (
+	"errors"
+	"github.com/mitchellgordon95/ConwaysGOL/display"
+	"github.com/mitchellgordon95/ConwaysGOL/files"
+	"math"
+	"strconv"
+	"strings"
+)
+
+func (tm *textManager) export(tokens []string) {
+	if len(tokens) < 2 {
+		tm.ShowMessage("Not enough arguments")
+		return
+	}
+
+	format, filename := tokens[0], tokens[1]
+	if format == "csv" {
+		if err := files.SavePopulationCsv(filename, tm.population.Samples()); err != nil {
+			tm.ShowMessage("Could not export the population history: " + err.Error())
+			return
+		}
+		tm.ShowMessage("Saved the population history to " + filename)
+		return
+	}
+	if format != "png" && format != "gif" && format != "frames" && format != "svg" {
+		tm.ShowMessage("Unknown export format " + format)
+		return
+	}
+
+	opts := files.SvgOptions{ImageOptions: display.DefaultImageOptions()}
+	var setter optionSetter = &opts.ImageOptions
+	if format == "svg" {
+		setter = &opts
+	}
+
+	anim, err := tm.parseExportOptions(tokens[2:], format == "gif" || format == "frames", setter)
+	if err != nil {
+		tm.ShowMessage(err.Error())
+		return
+	}
+
+	switch format {
+	case "png":
+		err = files.SavePng(tm.board, filename, anim.MinX, anim.MinY, anim.MaxX, anim.MaxY, opts.ImageOptions)
+	case "svg":
+		err = files.SaveSvg(tm.board, filename, anim.MinX, anim.MinY, anim.MaxX, anim.MaxY, opts)
+	case "gif":
+		err = files.SaveGif(tm.board, filename, anim, opts.ImageOptions)
+	case "frames":
+		var count int
+		count, err = files.SavePngSequence(tm.board, filename, anim, opts.ImageOptions)
+		filename = strconv.Itoa(count) + " files named like " + filename
+	}
+
+	if err != nil {
+		tm.ShowMessage("Could not export the board: " + err.Error())
+		return
+	}
+	tm.ShowMessage("Saved the board to " + filename)
+}
+
+// The animation used unless the user says otherwise
+var defaultAnimation = files.Animation{Frames: 100, Step: 1, Delay: 10}
+
+// Options for drawing the board that can be set by name
+type optionSetter interface {
+	Set(name, value string) error
+}
+
+/*
+Parses the options of the export command, which look like name=value. The region to draw, and for animations
+the number of frames, how far to step between them and so on, are returned as an animation.
+Since a single image is just one frame, its region is returned that way too. Any other options are passed on to opts.
+*/
+func (tm *textManager) parseExportOptions(tokens []string, animated bool, opts optionSetter) (files.Animation, error) {
+	anim := defaultAnimation
+	anim.MinX, anim.MinY, anim.MaxX, anim.MaxY = tm.viewBounds()
+
+	for _, token := range tokens {
+		name, value, err := splitOption(token)
+		if err != nil {
+			return anim, err
+		}
+
+		switch {
+		case name == "region" && value == "fit" && animated:
+			anim.Fit = true
+		case name == "region":
+			anim.Fit = false
+			anim.MinX, anim.MinY, anim.MaxX, anim.MaxY, err = tm.parseRegion(value)
+		case name == "frames" && animated:
+			anim.Frames, err = strconv.Atoi(value)
+			if err != nil || anim.Frames < 1 {
+				err = errors.New("Invalid number of frames")
+			}
+		case name == "step" && animated:
+			anim.Step, err = strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				err = errors.New("Invalid number of steps")
+			}
+		case name == "jump" && animated:
+			// Jumping by a power of 2 generations each frame is as fast as it gets with hashlife
+			var jump uint64
+			jump, err = strconv.ParseUint(value, 10, 8)
+			if err != nil || jump > 63 {
+				err = errors.New("Invalid jump")
+			}
+			anim.Step = 1 << jump
+		case name == "delay" && animated:
+			anim.Delay, err = strconv.Atoi(value)
+			if err != nil || anim.Delay < 0 {
+				err = errors.New("Invalid delay")
+			}
+		case name == "track" && animated:
+			if value != "on" && value != "off" {
+				err = errors.New("Expected track=on or track=off")
+			}
+			anim.Track = value == "on"
+		default:
+			err = opts.Set(name, value)
+		}
+
+		if err != nil {
+			return anim, err
+		}
+	}
+
+	return anim, nil
+}
+
+// Splits an option typed as name=value
+func splitOption(token string) (string, string, error) {
+	parts := strings.SplitN(token, "=", 2)
+	if len(parts) != 2 {
+		return "", "", errors.New("Options should look like name=value, not " + token)
+	}
+	return parts[0], parts[1], nil
+}
+
+// Parses a region of the board: "view" for the current view, "all" for the smallest region holding every alive cell,
+// or the corners "x1,y1,x2,y2". Returns the region from min coordinates (inclusive) to max coordinates (exclusive).
+func (tm *textManager) parseRegion(region string) (min_x, min_y, max_x, max_y int64, err error) {
+	switch region {
+	case "view":
+		min_x, min_y, max_x, max_y = tm.viewBounds()
+		return min_x, min_y, max_x, max_y, nil
+	case "all":
+		min_x, min_y, max_x, max_y, ok := tm.board.BoundingBox()
+		if !ok {
+			return 0, 0, 0, 0, errors.New("The board is empty")
+		}
+		if max_x == math.MaxInt64 || max_y == math.MaxInt64 {
+			return 0, 0, 0, 0, display.ErrRegionEdge
+		}
+		return min_x, min_y, max_x + 1, max_y + 1, nil
+	}
+	return display.ParseRegion(region)
+}
@@ -0,0 +1,43 @@
+package game_manager
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/hashlife"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var _ = Describe("Export", func() {
+	var tm *textManager
+	var shown *messages
+	var dir string
+	BeforeEach(func() {
+		shown = &messages{}
+		tm = NewTextManager(hashlife.NewHashLifeBoard().AddCell(0, 0), strings.NewReader(""), shown, 16, Options{}).(*textManager)
+		var err error
+		dir, err = ioutil.TempDir("", "export")
+		Expect(err).NotTo(HaveOccurred())
+	})
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("won't make an image with so many pixels across that the width wraps around", func() {
+		image := filepath.Join(dir, "board.png")
+		tm.run("export png " + image + " region=0,0,3,0 cell=4611686018427387905")
+		Expect(shown.shown).To(ContainElement(ContainSubstring("too big")))
+		Expect(image).NotTo(BeAnExistingFile())
+	})
+	It("won't take a region that ends past the edge of the board", func() {
+		tm.run("export png " + filepath.Join(dir, "board.png") + " region=0,0,9223372036854775807,0")
+		Expect(shown.shown).To(ContainElement("Regions can't reach coordinate 9223372036854775807"))
+	})
+	It("still saves a small image", func() {
+		image := filepath.Join(dir, "board.png")
+		tm.run("export png " + image + " region=0,0,3,3 zoom=2")
+		Expect(image).To(BeAnExistingFile())
+	})
+})
@@ -0,0 +1,133 @@
+package game_manager
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/files"
+	"github.com/mitchellgordon95/ConwaysGOL/pattern"
+	"os"
+	"strings"
+)
+
+// The most matches to list. The rest can still be gone through with find next.
+const maxFindResults = 20
+
+// The places a pattern was last found, so the view can be moved from one to the next
+type findResults struct {
+	name    string
+	pattern pattern.Pattern
+	matches []pattern.Match
+	// The match to center on next
+	next int
+}
+
+/*
+Looks for every copy of a pattern on the board, in any orientation. The pattern is a json file, a name from the library,
+or "clipboard" for whatever was last copied. Options look like name=value: border=on|off to only find copies with nothing
+alive right around them (on by default), and center=on|off to move the view to the first one. "find next" moves the view
+on to the next one.
+*/
+func (tm *textManager) find(tokens []string) {
+	if len(tokens) < 1 {
+		tm.ShowMessage("Not enough arguments")
+		return
+	}
+	if tokens[0] == "next" && len(tokens) == 1 {
+		tm.findNext()
+		return
+	}
+
+	var name []string
+	for len(tokens) > 0 && !strings.Contains(tokens[0], "=") {
+		name, tokens = append(name, tokens[0]), tokens[1:]
+	}
+	border, center := true, false
+	for _, token := range tokens {
+		option, value, err := splitOption(token)
+		if err == nil && value != "on" && value != "off" {
+			err = errors.New(option + " should be on or off")
+		}
+		if err != nil {
+			tm.ShowMessage(err.Error())
+			return
+		}
+		switch option {
+		case "border":
+			border = value == "on"
+		case "center":
+			center = value == "on"
+		default:
+			tm.ShowMessage("Unknown option " + option)
+			return
+		}
+	}
+
+	cells, err := tm.findPattern(strings.Join(name, " "))
+	if err != nil {
+		tm.ShowMessage(err.Error())
+		return
+	}
+	p, _, _ := pattern.FromCells(cells)
+	if p.Population() == 0 {
+		tm.ShowMessage("The pattern is empty")
+		return
+	}
+
+	matches := pattern.Find(tm.board, p, border)
+	tm.found = &findResults{name: strings.Join(name, " "), pattern: p, matches: matches}
+	if len(matches) == 0 {
+		tm.ShowMessage("Found no " + tm.found.name)
+		return
+	}
+
+	if center {
+		tm.findNext()
+	}
+	tm.ShowMessage(fmt.Sprintf("Found %d of %s:", len(matches), tm.found.name))
+	for i, match := range matches {
+		if i == maxFindResults {
+			tm.ShowMessage(fmt.Sprintf("    and %d more. Enter \"find next\" to go through them", len(matches)-i))
+			break
+		}
+		tm.ShowMessage(fmt.Sprintf("    (%d,%d) %v", match.X, match.Y, match.Orientation))
+	}
+}
+
+// Returns the cells of the pattern to find
+func (tm *textManager) findPattern(name string) ([]common.Cell, error) {
+	if name == "" {
+		return nil, errors.New("Not enough arguments")
+	}
+	if name == "clipboard" {
+		if tm.clipboard == nil {
+			return nil, errors.New("Nothing has been copied")
+		}
+		return tm.clipboard.Cells, nil
+	}
+	if _, err := os.Stat(name); err == nil {
+		return files.ReadJson(name)
+	}
+	entry, ok := tm.lib.Find(name)
+	if !ok {
+		return nil, errors.New("No file or library pattern named " + name)
+	}
+	return entry.Cells, nil
+}
+
+// Centers the view on the next place the pattern was found, starting over after the last one
+func (tm *textManager) findNext() {
+	if tm.found == nil || len(tm.found.matches) == 0 {
+		tm.ShowMessage("Nothing found yet. Try find [pattern]")
+		return
+	}
+
+	i := tm.found.next
+	match := tm.found.matches[i]
+	tm.found.next = (i + 1) % len(tm.found.matches)
+
+	oriented := tm.found.pattern.Orient(match.Orientation)
+	tm.centerX, tm.centerY = match.X+oriented.Width/2, match.Y+oriented.Height/2
+	tm.showBoard()
+	tm.ShowMessage(fmt.Sprintf("%s %d of %d at (%d,%d), %v", tm.found.name, i+1, len(tm.found.matches), match.X, match.Y, match.Orientation))
+}
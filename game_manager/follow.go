@@ -0,0 +1,94 @@
+package game_manager
+
+import (
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/analysis"
+	"math"
+)
+
+// What the view follows as the board runs
+type follower struct {
+	// The object being followed, or nil to follow the whole pattern
+	tracker *analysis.Tracker
+}
+
+/*
+Keeps the view on something as the board runs with next and animate, or goes to another generation with goto, undo,
+redo or snapshot load. "follow all" follows the middle of the whole
+pattern, "follow [x] [y]" follows the object at or nearest (x,y), like a spaceship, and "follow off" stops following.
+*/
+func (tm *textManager) follow(tokens []string) {
+	if len(tokens) < 1 {
+		switch {
+		case tm.following == nil:
+			tm.ShowMessage("Not following anything")
+		case tm.following.tracker == nil:
+			tm.ShowMessage("Following the whole pattern")
+		default:
+			t := tm.following.tracker
+			dx, dy := t.Velocity()
+			tm.ShowMessage(fmt.Sprintf("Following an object of %d cells at (%.1f,%.1f), moving (%.2f,%.2f) a generation", len(t.Cells), t.X, t.Y, dx, dy))
+		}
+		return
+	}
+
+	switch tokens[0] {
+	case "off":
+		tm.following = nil
+		tm.ShowMessage("Stopped following")
+		return
+	case "all":
+		tm.following = &follower{}
+		tm.recenter()
+		tm.showBoard()
+		tm.ShowMessage("Following the whole pattern")
+		return
+	}
+
+	if len(tokens) < 2 {
+		tm.ShowMessage("Not enough arguments")
+		return
+	}
+	x, y, err := parseCoordinates(tokens)
+	if err != nil {
+		tm.ShowMessage(err.Error())
+		return
+	}
+	tracker, ok := analysis.NewTracker(tm.board, x, y, tm.generation)
+	if !ok {
+		tm.ShowMessage("There's nothing near (" + tokens[0] + "," + tokens[1] + ") to follow")
+		return
+	}
+	tm.following = &follower{tracker}
+	tm.recenter()
+	tm.showBoard()
+	tm.ShowMessage(fmt.Sprintf("Following the object of %d cells near (%s,%s)", len(tracker.Cells), tokens[0], tokens[1]))
+}
+
+// Moves the view to whatever is being followed, if anything
+func (tm *textManager) recenter() {
+	if tm.following == nil {
+		return
+	}
+
+	if t := tm.following.tracker; t != nil {
+		if !t.Update(tm.board, tm.generation) {
+			tm.following = nil
+			tm.ShowMessage("Lost track of the object")
+			return
+		}
+		tm.centerX, tm.centerY = round(t.X), round(t.Y)
+		return
+	}
+
+	min_x, min_y, max_x, max_y, ok := tm.board.BoundingBox()
+	if !ok {
+		return
+	}
+	// Halve before adding so boards spread across the whole grid don't overflow
+	tm.centerX, tm.centerY = min_x/2+max_x/2, min_y/2+max_y/2
+}
+
+func round(x float64) int64 {
+	return int64(math.Floor(x + 0.5))
+}
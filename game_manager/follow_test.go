@@ -0,0 +1,38 @@
+package game_manager
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/hashlife"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"strings"
+)
+
+var _ = Describe("Follow", func() {
+	var tm *textManager
+	BeforeEach(func() {
+		// A glider heading down and to the right, a cell each way every 4 generations
+		glider := []common.Cell{{X: 1, Y: 2}, {X: 2, Y: 1}, {X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}
+		tm = NewTextManager(hashlife.NewHashLifeBoard().AddCells(glider), strings.NewReader(""), &messages{}, 16, Options{}).(*textManager)
+		tm.enter("follow 1 1", nil)
+	})
+
+	It("keeps up with goto", func() {
+		tm.enter("goto 400", nil)
+		Expect(tm.centerX).To(BeNumerically("~", 101, 1))
+		Expect(tm.centerY).To(BeNumerically("~", -99, 1))
+
+		tm.enter("goto 40", nil)
+		Expect(tm.centerX).To(BeNumerically("~", 11, 1))
+		Expect(tm.centerY).To(BeNumerically("~", -9, 1))
+	})
+	It("keeps up with undo and redo", func() {
+		tm.enter("next 400", nil)
+		tm.enter("undo", nil)
+		Expect(tm.centerX).To(BeNumerically("~", 1, 1))
+		Expect(tm.centerY).To(BeNumerically("~", 1, 1))
+
+		tm.enter("redo", nil)
+		Expect(tm.centerX).To(BeNumerically("~", 101, 1))
+	})
+})
@@ -1,5 +1,13 @@
 package game_manager
 
+// Settings for a manager that come from the command line
+type Options struct {
+	// A directory of json pattern files to add to the built in pattern library. Empty for none.
+	LibraryDir string
+	// A file of commands to run before reading any from the user. Empty for none.
+	Script string
+}
+
 type GolManager interface {
 	// Manages the game state until the user quits
 	Manage()
@@ -0,0 +1,13 @@
+package game_manager_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestGameManager(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Game Manager Suite")
+}
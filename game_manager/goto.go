@@ -0,0 +1,162 @@
+package game_manager
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"strconv"
+)
+
+// How many generations apart checkpoints are to start with
+const checkpointInterval = 128
+
+// The most checkpoints to keep while running, not counting the ones for edits. Once there are more, the interval doubles.
+const maxCheckpoints = 64
+
+// A board saved at some generation, so it can be gone back to without running from the start
+type checkpoint struct {
+	board common.GolBoard
+	// Whether the board was edited at this generation. Stepping forward from an earlier checkpoint wouldn't get the
+	// same board, so these are never thrown away.
+	edited bool
+}
+
+/*
+A sparse set of checkpoints along the run of the board, by generation. Boards share all the nodes they have in common,
+so checkpoints are cheap, but they're still spread further apart the longer the run goes on.
+*/
+type checkpointSet struct {
+	boards   map[uint64]checkpoint
+	interval uint64
+	// The number of checkpoints that aren't for edits
+	periodic int
+}
+
+func newCheckpointSet() *checkpointSet {
+	return &checkpointSet{boards: map[uint64]checkpoint{}, interval: checkpointInterval}
+}
+
+// Records that the board was changed at the given generation, so later checkpoints no longer lead to it
+func (cs *checkpointSet) edit(generation uint64, board common.GolBoard) {
+	cs.dropFrom(generation)
+	cs.boards[generation] = checkpoint{board, true}
+}
+
+/*
+Records that the board was stepped forward from one generation to another, saving it if it's time for a checkpoint.
+
+The checkpoints after the first generation might be from a run the user has since gone back on, like when they went back
+to before an edit and stepped forward again without making it. Stepping past an edit means that edit and everything after
+it didn't happen this time, and landing on a checkpoint with a different board means the same, so those are thrown away.
+*/
+func (cs *checkpointSet) stepped(from, to uint64, board common.GolBoard) {
+	for gen, cp := range cs.boards {
+		if gen > from && gen < to && cp.edited {
+			cs.dropFrom(gen)
+		}
+	}
+	if cp, ok := cs.boards[to]; ok && cp.board != board {
+		cs.dropFrom(to)
+	}
+
+	if to%cs.interval != 0 {
+		return
+	}
+	if _, ok := cs.boards[to]; ok {
+		return
+	}
+	cs.boards[to] = checkpoint{board, false}
+	cs.periodic++
+
+	if cs.periodic > maxCheckpoints {
+		// Spread the checkpoints out, keeping every other one
+		cs.interval *= 2
+		for gen, cp := range cs.boards {
+			if !cp.edited && gen%cs.interval != 0 {
+				delete(cs.boards, gen)
+				cs.periodic--
+			}
+		}
+	}
+}
+
+// Throws away the checkpoints at or after the given generation
+func (cs *checkpointSet) dropFrom(generation uint64) {
+	for gen, cp := range cs.boards {
+		if gen >= generation {
+			delete(cs.boards, gen)
+			if !cp.edited {
+				cs.periodic--
+			}
+		}
+	}
+}
+
+// Returns the latest checkpoint at or before the given generation
+func (cs *checkpointSet) before(generation uint64) (uint64, common.GolBoard, bool) {
+	best, found := uint64(0), false
+	for gen := range cs.boards {
+		if gen <= generation && (!found || gen > best) {
+			best, found = gen, true
+		}
+	}
+	if !found {
+		return 0, nil, false
+	}
+	return best, cs.boards[best].board, true
+}
+
+// Returns the boards at every checkpoint
+func (cs *checkpointSet) all() []common.GolBoard {
+	boards := make([]common.GolBoard, 0, len(cs.boards))
+	for _, cp := range cs.boards {
+		boards = append(boards, cp.board)
+	}
+	return boards
+}
+
+// Moves the board to the given generation, going back to the nearest checkpoint if it's in the past
+func (tm *textManager) gotoGeneration(tokens []string) {
+	if len(tokens) < 1 {
+		tm.ShowMessage("At generation " + strconv.FormatUint(tm.generation, 10))
+		return
+	}
+	target, err := strconv.ParseUint(tokens[0], 10, 64)
+	if err != nil {
+		tm.ShowMessage("Invalid generation")
+		return
+	}
+
+	// Step forward from the current board if we can, or from a checkpoint if that's closer
+	gen, board, ok := tm.checkpoints.before(target)
+	if target < tm.generation || (ok && gen > tm.generation) {
+		if !ok {
+			tm.ShowMessage("No checkpoint before generation " + tokens[0])
+			return
+		}
+		tm.rewind(gen, board)
+	}
+	tm.jump(target - tm.generation)
+
+	tm.recenter()
+	tm.showBoard()
+	tm.ShowMessage("Went to generation " + tokens[0])
+}
+
+// Puts the board back to a checkpoint. Unlike setBoard, this doesn't count as an edit.
+func (tm *textManager) rewind(generation uint64, board common.GolBoard) {
+	tm.board, tm.generation = board, generation
+	if tm.history != nil {
+		tm.history.Sync(board)
+	}
+	tm.population.Record(generation, board)
+}
+
+// Moves the board forward n generations as fast as possible. Unlike stepN, only the last generation goes in the
+// population history, and the cell history only sees the last two.
+func (tm *textManager) jump(n uint64) {
+	if n <= 1 {
+		tm.stepN(n)
+		return
+	}
+	tm.leap(n - 1)
+	tm.step()
+}
@@ -0,0 +1,86 @@
+package game_manager
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/hashlife"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checkpoints", func() {
+	var cs *checkpointSet
+	var glider common.GolBoard
+	BeforeEach(func() {
+		cs = newCheckpointSet()
+		glider = hashlife.NewHashLifeBoard().AddCells([]common.Cell{{X: 1, Y: 2}, {X: 2, Y: 1}, {X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}})
+		cs.edit(0, glider)
+	})
+
+	// Steps the board from one generation to another one generation at a time, the way the manager does
+	run := func(board common.GolBoard, from, to uint64) common.GolBoard {
+		for gen := from + 1; gen <= to; gen++ {
+			board = board.Step()
+			cs.stepped(gen-1, gen, board)
+		}
+		return board
+	}
+
+	It("saves the board every interval", func() {
+		run(glider, 0, 3*checkpointInterval+1)
+		gen, board, ok := cs.before(3*checkpointInterval + 1)
+		Expect(ok).To(BeTrue())
+		Expect(gen).To(Equal(uint64(3 * checkpointInterval)))
+		Expect(board).To(Equal(glider.StepN(3 * checkpointInterval)))
+	})
+	It("throws away the checkpoints after an edit", func() {
+		board := run(glider, 0, 4*checkpointInterval)
+		cs.edit(2*checkpointInterval+5, board.AddCell(100, 100))
+		gen, _, _ := cs.before(4 * checkpointInterval)
+		Expect(gen).To(Equal(uint64(2*checkpointInterval + 5)))
+		Expect(cs.periodic).To(Equal(2))
+	})
+	It("spreads the checkpoints out as the run goes on", func() {
+		run(glider, 0, (maxCheckpoints+1)*checkpointInterval)
+		Expect(cs.interval).To(Equal(uint64(2 * checkpointInterval)))
+		Expect(cs.periodic).To(Equal(maxCheckpoints / 2))
+		for gen, cp := range cs.boards {
+			Expect(cp.edited || gen%cs.interval == 0).To(BeTrue())
+		}
+		// The edit at the start is never thrown away
+		Expect(cs.boards).To(HaveKey(uint64(0)))
+	})
+	It("throws away checkpoints from a run that was gone back on", func() {
+		// Run to 500, edit the board there, and run on to 1000
+		board := run(glider, 0, 500).AddCell(-100, -100)
+		cs.edit(500, board)
+		edited := run(board, 500, 1000)
+
+		// Go back to 200 and run to 600 without the edit
+		_, board, _ = cs.before(200)
+		board = run(board, 128, 600)
+		Expect(board).NotTo(Equal(edited))
+
+		gen, checkpoint, ok := cs.before(600)
+		Expect(ok).To(BeTrue())
+		Expect(gen).To(Equal(uint64(512)))
+		Expect(checkpoint).To(Equal(glider.StepN(512)))
+		Expect(cs.boards).NotTo(HaveKey(uint64(500)))
+		Expect(cs.boards).NotTo(HaveKey(uint64(640)))
+	})
+	It("notices edits that were jumped over", func() {
+		board := run(glider, 0, 300).AddCell(-100, -100)
+		cs.edit(300, board)
+		run(board, 300, 400)
+
+		cs.stepped(0, 1000, glider.StepN(1000))
+		gen, _, _ := cs.before(1000)
+		Expect(gen).To(Equal(uint64(256)))
+	})
+	It("keeps the checkpoints when the same run is stepped through again", func() {
+		run(glider, 0, 600)
+		_, board, _ := cs.before(200)
+		run(board, 128, 600)
+		Expect(cs.boards).To(HaveKey(uint64(512)))
+		Expect(cs.periodic).To(Equal(4))
+	})
+})
@@ -0,0 +1,139 @@
+package game_manager
+
+import (
+	"crypto/subtle"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/display"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+/*
+Manages the game from a web browser. The page is served by a web displayer, and the commands the page sends
+back to "/command" are the same ones the text interface understands, so they're run by a text manager
+reading from the requests instead of a terminal.
+*/
+type guiManager struct {
+	*textManager
+	listener net.Listener
+	server   *http.Server
+	// Commands from the page are written to one end of a pipe, and read by the text manager from the other
+	commands *io.PipeWriter
+	reader   *io.PipeReader
+	// The hosts the page can be asked for by, and the secret it sends back with each command
+	hosts map[string]bool
+	token string
+}
+
+/*
+Creates a manager that serves the game on the given address (e.g. "127.0.0.1:8080"), using a web displayer
+to show the board. Starts listening right away, and returns the url of the page so it can be shown to the user.
+
+Commands can save files and run scripts, so only the page we serve can send them. Requests have to be for the address
+we're serving on, so other sites can't get at the server by pointing their own names at it, and commands have to come
+from our own page with the displayer's token.
+*/
+func NewGuiManager(board common.GolBoard, displayer display.WebDisplayer, width int64, addr string, opts Options) (GolManager, string, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, "", err
+	}
+
+	read, write := io.Pipe()
+	gm := &guiManager{
+		textManager: NewTextManager(board, read, displayer, width, opts).(*textManager),
+		listener:    listener,
+		commands:    write,
+		reader:      read,
+		hosts:       servedHosts(addr, listener.Addr()),
+		token:       displayer.Token(),
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", displayer)
+	mux.HandleFunc("/command", gm.command)
+	gm.server = &http.Server{Handler: gm.checkHost(mux)}
+
+	return gm, "http://" + listener.Addr().String() + "/", nil
+}
+
+func (gm *guiManager) Manage() {
+	go gm.server.Serve(gm.listener)
+	defer gm.server.Close()
+	// Once we're done, any commands still on their way get an error instead of waiting forever
+	defer gm.reader.Close()
+
+	// Draw the board once, so the page has something to show as soon as it opens
+	gm.showBoard()
+	openBrowser("http://" + gm.listener.Addr().String() + "/")
+	gm.textManager.Manage()
+}
+
+// Returns the hosts a request can name to get to a server listening on the given address
+func servedHosts(addr string, listening net.Addr) map[string]bool {
+	hosts := map[string]bool{addr: true, listening.String(): true}
+	if host, port, err := net.SplitHostPort(listening.String()); err == nil {
+		if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+			hosts["localhost:"+port] = true
+		}
+	}
+	return hosts
+}
+
+// Turns away requests that aren't for the address we're serving on
+func (gm *guiManager) checkHost(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !gm.hosts[r.Host] {
+			http.Error(w, "unknown host "+r.Host, http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Passes a command from the page on to the text manager
+func (gm *guiManager) command(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "commands must be POSTed", http.StatusMethodNotAllowed)
+		return
+	}
+	if origin := r.Header.Get("Origin"); origin != "" && origin != "http://"+r.Host {
+		http.Error(w, "commands can only come from the game's own page", http.StatusForbidden)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get(display.TokenHeader)), []byte(gm.token)) != 1 {
+		http.Error(w, "missing or wrong token", http.StatusForbidden)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Only take the first line, so one request can't smuggle in more commands
+	cmd := strings.SplitN(strings.TrimSpace(string(body)), "\n", 2)[0]
+	if _, err := io.WriteString(gm.commands, cmd+"\n"); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	}
+}
+
+// Tries to open a url in the user's browser. There might not be one (e.g. on a headless machine), so errors are ignored.
+func openBrowser(url string) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("cmd", "/c", "start", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	cmd.Start()
+}
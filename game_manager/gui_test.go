@@ -0,0 +1,78 @@
+package game_manager
+
+import (
+	"bufio"
+	"github.com/mitchellgordon95/ConwaysGOL/display"
+	"github.com/mitchellgordon95/ConwaysGOL/hashlife"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+var _ = Describe("GUI", func() {
+	var gm *guiManager
+	var host, token string
+	BeforeEach(func() {
+		displayer, err := display.NewWebDisplayer()
+		Expect(err).NotTo(HaveOccurred())
+		manager, _, err := NewGuiManager(hashlife.NewHashLifeBoard(), displayer, 16, "127.0.0.1:0", Options{})
+		Expect(err).NotTo(HaveOccurred())
+		gm = manager.(*guiManager)
+		host, token = gm.listener.Addr().String(), displayer.Token()
+	})
+	AfterEach(func() {
+		gm.listener.Close()
+		gm.reader.Close()
+	})
+
+	send := func(r *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		gm.server.Handler.ServeHTTP(w, r)
+		return w
+	}
+	command := func(cmd string) *http.Request {
+		r := httptest.NewRequest("POST", "http://"+host+"/command", strings.NewReader(cmd))
+		r.Header.Set(display.TokenHeader, token)
+		r.Header.Set("Origin", "http://"+host)
+		return r
+	}
+
+	It("puts the token in the page", func() {
+		w := send(httptest.NewRequest("GET", "http://"+host+"/", nil))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(ContainSubstring(token))
+		Expect(token).To(HaveLen(32))
+	})
+	It("passes on commands from the page", func() {
+		received := make(chan string, 1)
+		go func() {
+			line, _ := bufio.NewReader(gm.reader).ReadString('\n')
+			received <- line
+		}()
+		Expect(send(command("next 5")).Code).To(Equal(http.StatusOK))
+		Eventually(received).Should(Receive(Equal("next 5\n")))
+	})
+	It("turns away commands without the token", func() {
+		r := command("save /tmp/board.json")
+		r.Header.Del(display.TokenHeader)
+		Expect(send(r).Code).To(Equal(http.StatusForbidden))
+		r = command("save /tmp/board.json")
+		r.Header.Set(display.TokenHeader, "wrong")
+		Expect(send(r).Code).To(Equal(http.StatusForbidden))
+	})
+	It("turns away commands from other sites", func() {
+		r := command("save /tmp/board.json")
+		r.Header.Set("Origin", "http://evil.example.com")
+		Expect(send(r).Code).To(Equal(http.StatusForbidden))
+	})
+	It("turns away requests for other hosts", func() {
+		r := httptest.NewRequest("GET", "http://evil.example.com/", nil)
+		w := send(r)
+		Expect(w.Code).To(Equal(http.StatusForbidden))
+		body, _ := ioutil.ReadAll(w.Body)
+		Expect(string(body)).NotTo(ContainSubstring(token))
+	})
+})
@@ -0,0 +1,95 @@
+package game_manager
+
+import (
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/library"
+	"strings"
+)
+
+func (tm *textManager) library(tokens []string) {
+	if len(tokens) < 1 {
+		tokens = []string{"list"}
+	}
+
+	switch tokens[0] {
+	case "list":
+		category := strings.Join(tokens[1:], " ")
+		entries := tm.lib.List(category)
+		if len(entries) == 0 {
+			tm.ShowMessage("No patterns in " + category + ". The categories are " + strings.Join(tm.lib.Categories(), ", "))
+			return
+		}
+		tm.showEntries(entries)
+	case "search":
+		if len(tokens) < 2 {
+			tm.ShowMessage("Not enough arguments")
+			return
+		}
+		entries := tm.lib.Search(strings.Join(tokens[1:], " "))
+		if len(entries) == 0 {
+			tm.ShowMessage("No patterns found")
+			return
+		}
+		tm.showEntries(entries)
+	case "load":
+		tm.loadFromLibrary(tokens[1:])
+	case "dir":
+		if len(tokens) < 2 {
+			tm.ShowMessage("Not enough arguments")
+			return
+		}
+		if err := tm.lib.AddDir(tokens[1]); err != nil {
+			tm.ShowMessage("Could not read the library: " + err.Error())
+			return
+		}
+		tm.ShowMessage("Added the patterns in " + tokens[1] + " to the library")
+	default:
+		tm.ShowMessage("Expected library list, search, load or dir")
+	}
+}
+
+func (tm *textManager) showEntries(entries []library.Entry) {
+	tm.ShowMessage(fmt.Sprintf("%-24s  %-12s  %6s  %5s  %s", "NAME", "CATEGORY", "PERIOD", "SPEED", "CELLS"))
+	for _, entry := range entries {
+		period := "-"
+		if entry.Period > 0 {
+			period = fmt.Sprint(entry.Period)
+		}
+		speed := entry.Speed
+		if speed == "" {
+			speed = "-"
+		}
+		tm.ShowMessage(fmt.Sprintf("%-24s  %-12s  %6s  %5s  %d", entry.Name, entry.Category, period, speed, len(entry.Cells)))
+	}
+}
+
+// Loads a pattern from the library. The name can have spaces in it, and is followed by the same options as load.
+func (tm *textManager) loadFromLibrary(tokens []string) {
+	var name []string
+	for len(tokens) > 0 && !strings.Contains(tokens[0], "=") {
+		name, tokens = append(name, tokens[0]), tokens[1:]
+	}
+	if len(name) == 0 {
+		tm.ShowMessage("Not enough arguments")
+		return
+	}
+
+	entry, ok := tm.lib.Find(strings.Join(name, " "))
+	if !ok {
+		tm.ShowMessage("No pattern named " + strings.Join(name, " ") + ". Try library search")
+		return
+	}
+	p, err := parsePlacement(tokens)
+	if err != nil {
+		tm.ShowMessage(err.Error())
+		return
+	}
+
+	tm.place(entry.Cells, p, entry.Name)
+	tm.showBoard()
+	msg := "Loaded " + entry.Name
+	if entry.Description != "" {
+		msg += ". " + entry.Description
+	}
+	tm.ShowMessage(msg)
+}
@@ -0,0 +1,219 @@
+package game_manager
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/files"
+	"github.com/mitchellgordon95/ConwaysGOL/pattern"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Where and how to put a pattern on the board
+type placement struct {
+	// How far to move the pattern from the center of the view
+	dx, dy int64
+	// How to turn the pattern around, about its own (0, 0)
+	orientation pattern.Orientation
+	// How many generations to run the pattern before adding it to the board
+	advance uint64
+	// The layer to put the pattern in, or "" for none
+	layer string
+}
+
+// Parses placement options, which look like name=value
+func parsePlacement(tokens []string) (placement, error) {
+	var p placement
+	for _, token := range tokens {
+		name, value, err := splitOption(token)
+		switch {
+		case err != nil:
+		case name == "offset":
+			parts := strings.Split(value, ",")
+			if len(parts) != 2 {
+				err = errors.New("Expected offset=[dx],[dy]")
+				break
+			}
+			p.dx, p.dy, err = parseCoordinates(parts)
+		case name == "rotate":
+			var degrees int
+			degrees, err = strconv.Atoi(value)
+			if err != nil || degrees%90 != 0 {
+				err = errors.New("Rotations have to be a multiple of 90 degrees")
+			}
+			p.orientation.Turns = (degrees/90%4 + 4) % 4
+		case name == "flip":
+			if value != "on" && value != "off" {
+				err = errors.New("Expected flip=on or flip=off")
+			}
+			p.orientation.Flip = value == "on"
+		case name == "advance":
+			p.advance, err = strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				err = errors.New("Invalid number of generations")
+			}
+		case name == "layer":
+			p.layer = value
+		default:
+			err = errors.New("Unknown option " + name)
+		}
+
+		if err != nil {
+			return p, err
+		}
+	}
+	return p, nil
+}
+
+// A pattern loaded into a named layer, so it can be replaced or taken off again
+type layer struct {
+	// The cells the layer added to the board
+	board common.GolBoard
+	// The generation it was added at. Once the board has moved on, the layer's cells aren't where they were.
+	generation uint64
+	source     string
+}
+
+// Returns whether the layer is still on the board: it was added at the current generation, and none of its cells have
+// been killed since, by undo for instance
+func (tm *textManager) layerValid(l layer) bool {
+	return l.on(edit{board: tm.board, generation: tm.generation})
+}
+
+// Returns whether the layer is on the board in a state of it
+func (l layer) on(state edit) bool {
+	return l.generation == state.generation && l.board.Subtract(state.board).Population() == 0
+}
+
+/*
+Forgets the layers that are off the board and can't come back by undoing or redoing, so the garbage collector can free
+their cells
+*/
+func (tm *textManager) pruneLayers() {
+	states := append([]edit{{board: tm.board, generation: tm.generation}}, tm.undone...)
+	states = append(states, tm.redoable...)
+	for name, l := range tm.layers {
+		kept := false
+		for _, state := range states {
+			if l.on(state) {
+				kept = true
+				break
+			}
+		}
+		if !kept {
+			delete(tm.layers, name)
+		}
+	}
+}
+
+// Loads a json file onto the board, placed as the options say
+func (tm *textManager) load(tokens []string) {
+	if len(tokens) < 1 {
+		tm.ShowMessage("Not enough arguments")
+		return
+	}
+
+	p, err := parsePlacement(tokens[1:])
+	if err != nil {
+		tm.ShowMessage(err.Error())
+		return
+	}
+	cells, err := files.ReadJson(tokens[0])
+	if err != nil {
+		tm.ShowMessage("Could not load board: " + err.Error())
+		return
+	}
+
+	tm.place(cells, p, tokens[0])
+	tm.showBoard()
+	tm.ShowMessage("Loaded file onto board.")
+}
+
+/*
+Adds cells given relative to the center of the view to the board: turned around about (0, 0), moved, and then run
+forward if the placement says to. If it names a layer, the cells go in the layer, replacing what was in it.
+*/
+func (tm *textManager) place(cells []common.Cell, p placement, source string) {
+	placed := make([]common.Cell, len(cells))
+	for i, cell := range cells {
+		x, y := p.orientation.Apply(cell.X, cell.Y)
+		placed[i] = common.Cell{X: x + tm.centerX + p.dx, Y: y + tm.centerY + p.dy}
+	}
+	piece := tm.board.Clear().AddCells(placed).StepN(p.advance)
+
+	board := tm.board
+	if p.layer != "" {
+		board = tm.removeLayer(p.layer)
+		tm.layers[p.layer] = layer{piece, tm.generation, source}
+	}
+	tm.setBoard(board.Union(piece))
+}
+
+/*
+Returns the board without the cells in a layer. Cells that are also in other layers stay. The layer is still remembered,
+so it comes back if the removal is undone.
+*/
+func (tm *textManager) removeLayer(name string) common.GolBoard {
+	l, ok := tm.layers[name]
+	if !ok || !tm.layerValid(l) {
+		return tm.board
+	}
+
+	board := tm.board.Subtract(l.board)
+	for _, other := range tm.currentLayers() {
+		if other != name {
+			board = board.Union(tm.layers[other].board)
+		}
+	}
+	return board
+}
+
+// Returns the names of the layers still on the board, in order
+func (tm *textManager) currentLayers() []string {
+	var names []string
+	for name, l := range tm.layers {
+		if tm.layerValid(l) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (tm *textManager) layer(tokens []string) {
+	if len(tokens) < 1 {
+		tm.ShowMessage("Not enough arguments")
+		return
+	}
+
+	switch tokens[0] {
+	case "list":
+		names := tm.currentLayers()
+		if len(names) == 0 {
+			tm.ShowMessage("No layers on the board")
+			return
+		}
+		for _, name := range names {
+			l := tm.layers[name]
+			min_x, min_y, max_x, max_y, _ := l.board.BoundingBox()
+			tm.ShowMessage(fmt.Sprintf("%s: %d cells from %s, in (%d,%d) to (%d,%d)",
+				name, l.board.Population(), l.source, min_x, min_y, max_x, max_y))
+		}
+	case "remove":
+		if len(tokens) < 2 {
+			tm.ShowMessage("Not enough arguments")
+			return
+		}
+		if l, ok := tm.layers[tokens[1]]; !ok || !tm.layerValid(l) {
+			tm.ShowMessage("No layer named " + tokens[1] + " on the board")
+			return
+		}
+		tm.setBoard(tm.removeLayer(tokens[1]))
+		tm.showBoard()
+		tm.ShowMessage("Removed layer " + tokens[1])
+	default:
+		tm.ShowMessage("Expected layer list or layer remove")
+	}
+}
@@ -0,0 +1,35 @@
+package game_manager
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/hashlife"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"strings"
+)
+
+var _ = Describe("Layers", func() {
+	var tm *textManager
+	var shown *messages
+	BeforeEach(func() {
+		shown = &messages{}
+		tm = NewTextManager(hashlife.NewHashLifeBoard(), strings.NewReader(""), shown, 16, Options{}).(*textManager)
+		block := []common.Cell{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}}
+		tm.place(block, placement{layer: "block"}, "block.json")
+	})
+
+	It("keeps a layer that undoing can bring back", func() {
+		tm.enter("next", nil)
+		Expect(tm.currentLayers()).To(BeEmpty())
+		Expect(tm.layers).To(HaveKey("block"))
+
+		tm.enter("undo", nil)
+		Expect(tm.currentLayers()).To(Equal([]string{"block"}))
+	})
+
+	It("forgets a layer once nothing can bring it back", func() {
+		tm.enter("next", nil)
+		tm.enter("history limit 0", nil)
+		Expect(tm.layers).To(BeEmpty())
+	})
+})
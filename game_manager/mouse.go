@@ -0,0 +1,134 @@
+package game_manager
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Mouse buttons reported by the terminal
+const (
+	leftButton   = 0
+	middleButton = 1
+	rightButton  = 2
+)
+
+// A mouse event reported by the terminal using xterm's SGR encoding (ESC [ < b ; col ; row M)
+type mouseEvent struct {
+	button   int
+	col, row int
+	// Whether the event came from moving the mouse with a button held down
+	drag bool
+	// Whether the button was released
+	release bool
+}
+
+/*
+A mouseReader reads from a terminal in non-canonical mode. It takes care of line editing and echoing the
+characters the user types, and hands any mouse events it finds in the input to a handler.
+Reads only ever return whole lines, so the reader can be used in place of a line buffered terminal.
+*/
+type mouseReader struct {
+	in *bufio.Reader
+	// Where to echo the characters the user types
+	echo io.Writer
+	// Called for every mouse event
+	handle func(mouseEvent)
+	// The line currently being typed
+	line []byte
+	// A completed line that hasn't been read yet
+	pending []byte
+}
+
+func newMouseReader(in io.Reader, echo io.Writer, handle func(mouseEvent)) *mouseReader {
+	return &mouseReader{in: bufio.NewReader(in), echo: echo, handle: handle}
+}
+
+func (mr *mouseReader) Read(p []byte) (int, error) {
+	for len(mr.pending) == 0 {
+		b, err := mr.in.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+
+		switch {
+		case b == '\033':
+			mr.escape()
+		case b == '\r' || b == '\n':
+			mr.echo.Write([]byte("\r\n"))
+			mr.pending = append(mr.line, '\n')
+			mr.line = nil
+		case b == 0x7f || b == '\b':
+			if len(mr.line) > 0 {
+				mr.line = mr.line[:len(mr.line)-1]
+				mr.echo.Write([]byte("\b \b"))
+			}
+		case b >= ' ' && b < 0x7f:
+			mr.line = append(mr.line, b)
+			mr.echo.Write([]byte{b})
+		}
+	}
+
+	n := copy(p, mr.pending)
+	mr.pending = mr.pending[n:]
+	return n, nil
+}
+
+// Reads the rest of an escape sequence. Mouse events are passed to the handler, anything else (e.g. arrow keys) is dropped.
+func (mr *mouseReader) escape() {
+	b, err := mr.in.ReadByte()
+	if err != nil || b != '[' {
+		return
+	}
+
+	// Control sequences end with a byte in the range @ to ~
+	var seq []byte
+	for {
+		b, err = mr.in.ReadByte()
+		if err != nil {
+			return
+		}
+		if b >= '@' && b <= '~' {
+			break
+		}
+		seq = append(seq, b)
+	}
+
+	if len(seq) == 0 || seq[0] != '<' || (b != 'M' && b != 'm') {
+		return
+	}
+
+	ev, ok := parseMouseEvent(string(seq[1:]), b == 'm')
+	if ok {
+		mr.handle(ev)
+		// The handler may have redrawn the screen, so show the partially typed line again
+		mr.echo.Write(mr.line)
+	}
+}
+
+// Parses the "b;col;row" parameters of an SGR mouse report
+func parseMouseEvent(params string, release bool) (mouseEvent, bool) {
+	fields := strings.Split(params, ";")
+	if len(fields) != 3 {
+		return mouseEvent{}, false
+	}
+
+	var vals [3]int
+	for i, field := range fields {
+		val, err := strconv.Atoi(field)
+		if err != nil {
+			return mouseEvent{}, false
+		}
+		vals[i] = val
+	}
+
+	return mouseEvent{
+		// The low two bits are the button, bit 5 is set for motion. Bit 6 marks the scroll wheel, which we treat as its own button.
+		button:  vals[0] & 0x43,
+		drag:    vals[0]&0x20 != 0,
+		col:     vals[1],
+		row:     vals[2],
+		release: release,
+	}, true
+}
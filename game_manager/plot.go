@@ -0,0 +1,72 @@
+package game_manager
+
+import (
+	"errors"
+	"github.com/mitchellgordon95/ConwaysGOL/display"
+	"strconv"
+)
+
+// The number of generations of population to remember
+const populationHistorySize = 10000
+
+// The size of the population chart, unless the user says otherwise
+const (
+	defaultPlotWidth  = 60
+	defaultPlotHeight = 12
+)
+
+// Shows a chart of the population over the latest generations
+func (tm *textManager) plot(tokens []string) {
+	width, height := defaultPlotWidth, defaultPlotHeight
+	style := "unicode"
+	last := uint64(0)
+
+	for _, token := range tokens {
+		name, value, err := splitOption(token)
+		switch {
+		case err != nil:
+		case name == "width" || name == "height":
+			var n int
+			n, err = strconv.Atoi(value)
+			if err != nil || n < 1 {
+				err = errors.New("Invalid " + name)
+			} else if name == "width" {
+				width = n
+			} else {
+				height = n
+			}
+		case name == "last":
+			last, err = strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				err = errors.New("Invalid number of generations")
+			}
+		case name == "style":
+			if value != "unicode" && value != "ascii" && value != "spark" {
+				err = errors.New("Expected style=unicode, style=ascii or style=spark")
+			}
+			style = value
+		default:
+			err = errors.New("Unknown option " + name)
+		}
+
+		if err != nil {
+			tm.ShowMessage(err.Error())
+			return
+		}
+	}
+
+	samples := tm.population.Samples()
+	if last > 0 {
+		for len(samples) > 1 && samples[0].Generation+last < tm.generation {
+			samples = samples[1:]
+		}
+	}
+
+	if style == "spark" {
+		tm.ShowMessage(display.Sparkline(samples, width))
+		return
+	}
+	for _, line := range display.Chart(samples, width, height, style == "ascii") {
+		tm.ShowMessage(line)
+	}
+}
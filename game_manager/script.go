@@ -0,0 +1,299 @@
+package game_manager
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// How deep scripts can source other scripts, so one that sources itself doesn't go on forever
+const maxSourceDepth = 16
+
+/*
+Runs a line of commands. Before it's run, anything from a word starting with # on is a comment and is dropped (a # in the
+middle of a word, like in alive=#ff0000, isn't), and
+variables like $name or ${name} are replaced with their values. A line like "repeat N {" runs the lines up to the
+matching "}" N times, reading them with more. Returns false if the user quit.
+*/
+func (tm *textManager) execute(line string, more func() (string, error)) bool {
+	tokens := fields(line)
+	if len(tokens) == 0 {
+		return true
+	}
+
+	// The name of the variable being set isn't replaced, and neither is the command being repeated, since that's replaced
+	// each time it runs
+	first, last := 0, len(tokens)
+	switch tokens[0] {
+	case "set", "unset":
+		first = 2
+	case "repeat":
+		if last > 2 {
+			last = 2
+		}
+	}
+	for i := first; i < last; i++ {
+		token, err := tm.substitute(tokens[i])
+		if err != nil {
+			tm.ShowMessage(err.Error())
+			return true
+		}
+		tokens[i] = token
+	}
+
+	switch tokens[0] {
+	case "repeat":
+		return tm.repeat(tokens[1:], more)
+	case "source":
+		return tm.source(tokens[1:])
+	case "set":
+		tm.set(tokens[1:])
+	case "unset":
+		if len(tokens) < 2 {
+			tm.ShowMessage("Not enough arguments")
+			return true
+		}
+		delete(tm.variables, tokens[1])
+	default:
+		return tm.run(strings.Join(tokens, " "))
+	}
+	return true
+}
+
+// Runs a line the user typed. However many commands it runs, they're undone all at once. Returns false if the user quit.
+func (tm *textManager) enter(line string, more func() (string, error)) bool {
+	return tm.undoable(strings.Join(fields(line), " "), func() bool { return tm.execute(line, more) })
+}
+
+// Splits a line into tokens, leaving out the comment at the end if there is one
+func fields(line string) []string {
+	tokens := strings.Fields(line)
+	for i, token := range tokens {
+		if strings.HasPrefix(token, "#") {
+			return tokens[:i]
+		}
+	}
+	return tokens
+}
+
+// Runs the commands in script files, one line at a time. Returns false if one of them quit.
+func (tm *textManager) source(tokens []string) bool {
+	if len(tokens) < 1 {
+		tm.ShowMessage("Not enough arguments")
+		return true
+	}
+	if tm.sourceDepth >= maxSourceDepth {
+		tm.ShowMessage("Scripts are sourcing each other too deep, not running " + tokens[0])
+		return true
+	}
+
+	file, err := os.Open(tokens[0])
+	if err != nil {
+		tm.ShowMessage("Could not read the script: " + err.Error())
+		return true
+	}
+	defer file.Close()
+
+	tm.sourceDepth++
+	defer func() { tm.sourceDepth-- }()
+
+	return tm.runLines(file)
+}
+
+// Runs the commands in a script, one line at a time. Returns false if one of them quit.
+func (tm *textManager) runLines(script io.Reader) bool {
+	reader := bufio.NewReader(script)
+	readLine := func() (string, error) {
+		return reader.ReadString('\n')
+	}
+	for {
+		line, err := readLine()
+		if err != nil && err != io.EOF {
+			tm.ShowMessage("Could not read the script: " + err.Error())
+			return true
+		}
+		if !tm.execute(line, readLine) {
+			return false
+		}
+		if err == io.EOF {
+			return true
+		}
+	}
+}
+
+/*
+Runs a command over and over. "repeat N [command]" runs a single command N times, and "repeat N {" runs the lines
+up to the matching "}" N times. Returns false if one of the commands quit.
+*/
+func (tm *textManager) repeat(tokens []string, more func() (string, error)) bool {
+	if len(tokens) < 2 {
+		tm.ShowMessage("Not enough arguments")
+		return true
+	}
+	times, err := strconv.ParseUint(tokens[0], 10, 64)
+	if err != nil {
+		tm.ShowMessage("Invalid number of times to repeat")
+		return true
+	}
+
+	body := []string{strings.Join(tokens[1:], " ")}
+	if tokens[len(tokens)-1] == "{" {
+		if len(tokens) > 2 {
+			tm.ShowMessage("Put the commands to repeat on the lines after the {")
+			return true
+		}
+		if body, err = block(more); err != nil {
+			tm.ShowMessage(err.Error())
+			return true
+		}
+	}
+
+	for i := uint64(0); i < times; i++ {
+		lines := body
+		next := func() (string, error) {
+			if len(lines) == 0 {
+				return "", io.EOF
+			}
+			line := lines[0]
+			lines = lines[1:]
+			return line, nil
+		}
+		for len(lines) > 0 {
+			line, _ := next()
+			if !tm.execute(line, next) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Reads lines up to the "}" that ends a block, and returns the ones in between
+func block(more func() (string, error)) ([]string, error) {
+	var lines []string
+	depth := 1
+	for {
+		line, err := more()
+		tokens := fields(line)
+		switch {
+		case len(tokens) == 1 && tokens[0] == "}":
+			depth--
+			if depth == 0 {
+				return lines, nil
+			}
+		case len(tokens) > 0 && tokens[len(tokens)-1] == "{":
+			depth++
+		}
+		lines = append(lines, line)
+
+		if err != nil {
+			return nil, errors.New("Missing } at the end of the block")
+		}
+	}
+}
+
+// Sets a variable, or lists them all if there's no name
+func (tm *textManager) set(tokens []string) {
+	if len(tokens) == 0 {
+		names := make([]string, 0, len(tm.variables))
+		for name := range tm.variables {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			tm.ShowMessage(name + " = " + tm.variables[name])
+		}
+		tm.ShowMessage("generation = " + strconv.FormatUint(tm.generation, 10) + " and population = " +
+			strconv.FormatUint(tm.board.Population(), 10) + " are always set")
+		return
+	}
+	if len(tokens) < 2 {
+		tm.ShowMessage("Not enough arguments")
+		return
+	}
+	if !validName(tokens[0]) {
+		tm.ShowMessage("Variable names can only have letters, digits and _ in them")
+		return
+	}
+	tm.variables[tokens[0]] = strings.Join(tokens[1:], " ")
+}
+
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if !nameChar(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func nameChar(c rune) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// Replaces the variables in a token with their values
+func (tm *textManager) substitute(token string) (string, error) {
+	if !strings.Contains(token, "$") {
+		return token, nil
+	}
+
+	out := []rune{}
+	runes := []rune(token)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '$' {
+			out = append(out, runes[i])
+			continue
+		}
+
+		// The name is either in braces, or as many letters, digits and _ as follow the $
+		var name string
+		if i+1 < len(runes) && runes[i+1] == '{' {
+			end := i + 2
+			for end < len(runes) && runes[end] != '}' {
+				end++
+			}
+			if end == len(runes) {
+				return "", errors.New("Missing } in " + token)
+			}
+			name, i = string(runes[i+2:end]), end
+		} else {
+			end := i + 1
+			for end < len(runes) && nameChar(runes[end]) {
+				end++
+			}
+			name, i = string(runes[i+1:end]), end-1
+		}
+
+		if name == "" {
+			return "", errors.New("Expected a variable name after the $ in " + token)
+		}
+		value, ok := tm.variable(name)
+		if !ok {
+			return "", fmt.Errorf("Undefined variable $%s", name)
+		}
+		out = append(out, []rune(value)...)
+	}
+	return string(out), nil
+}
+
+// Returns the value of a variable. The generation and population are always set, unless the user sets them to something else.
+func (tm *textManager) variable(name string) (string, bool) {
+	if value, ok := tm.variables[name]; ok {
+		return value, true
+	}
+	switch name {
+	case "generation":
+		return strconv.FormatUint(tm.generation, 10), true
+	case "population":
+		return strconv.FormatUint(tm.board.Population(), 10), true
+	}
+	return "", false
+}
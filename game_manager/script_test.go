@@ -0,0 +1,177 @@
+package game_manager
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/hashlife"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// A displayer that keeps the messages it's asked to show, and throws the boards away
+type messages struct {
+	shown []string
+}
+
+func (m *messages) Display(board common.GolBoard, min_x, min_y, max_x, max_y int64) {}
+
+func (m *messages) ShowMessage(msg string) {
+	m.shown = append(m.shown, msg)
+}
+
+var _ = Describe("Scripts", func() {
+	var tm *textManager
+	var shown *messages
+	BeforeEach(func() {
+		shown = &messages{}
+		tm = NewTextManager(hashlife.NewHashLifeBoard(), strings.NewReader(""), shown, 16, Options{}).(*textManager)
+	})
+
+	// Runs a script, and returns false if it quit
+	run := func(lines ...string) bool {
+		return tm.runLines(strings.NewReader(strings.Join(lines, "\n")))
+	}
+
+	Describe("fields", func() {
+		It("splits a line into words", func() {
+			Expect(fields("  alive 1\t2 \n")).To(Equal([]string{"alive", "1", "2"}))
+			Expect(fields("")).To(BeEmpty())
+		})
+		It("drops comments", func() {
+			Expect(fields("alive 1 2 # a comment")).To(Equal([]string{"alive", "1", "2"}))
+			Expect(fields("#alive 1 2")).To(BeEmpty())
+		})
+		It("keeps a # in the middle of a word", func() {
+			Expect(fields("export png a.png alive=#ff0000")).To(HaveLen(4))
+		})
+	})
+
+	Describe("substitute", func() {
+		BeforeEach(func() {
+			tm.variables["name"] = "glider"
+		})
+		It("replaces variables with their values", func() {
+			Expect(tm.substitute("$name")).To(Equal("glider"))
+			Expect(tm.substitute("a-${name}s.json")).To(Equal("a-gliders.json"))
+			Expect(tm.substitute("$name.json")).To(Equal("glider.json"))
+			Expect(tm.substitute("plain")).To(Equal("plain"))
+		})
+		It("always knows the generation and population", func() {
+			tm.board = tm.board.AddCell(0, 0)
+			Expect(tm.substitute("$generation/$population")).To(Equal("0/1"))
+		})
+		It("complains about variables that aren't set", func() {
+			_, err := tm.substitute("$missing")
+			Expect(err).To(MatchError("Undefined variable $missing"))
+		})
+		It("complains about bad variable names", func() {
+			_, err := tm.substitute("${}")
+			Expect(err).To(HaveOccurred())
+			_, err = tm.substitute("a$")
+			Expect(err).To(HaveOccurred())
+			_, err = tm.substitute("${name")
+			Expect(err).To(MatchError("Missing } in ${name"))
+		})
+	})
+
+	Describe("execute", func() {
+		It("sets and uses variables", func() {
+			Expect(run("set x 3", "set y -2", "alive $x $y")).To(BeTrue())
+			Expect(tm.board.IsAlive(3, -2)).To(BeTrue())
+			Expect(run("unset x", "alive $x 0")).To(BeTrue())
+			Expect(tm.board.Population()).To(Equal(uint64(1)))
+			Expect(shown.shown).To(ContainElement("Undefined variable $x"))
+		})
+		It("repeats a single command", func() {
+			run("repeat 5 next")
+			Expect(tm.generation).To(Equal(uint64(5)))
+		})
+		It("replaces the variables in a repeated command once", func() {
+			tm.variables["cmd"] = "$missing"
+			Expect(run("set n 2", "repeat $n set last $cmd")).To(BeTrue())
+			Expect(tm.variables["last"]).To(Equal("$missing"))
+			Expect(shown.shown).NotTo(ContainElement("Undefined variable $missing"))
+		})
+		It("repeats blocks inside blocks", func() {
+			run(
+				"repeat 3 {",
+				"    next # one",
+				"    repeat 2 {",
+				"        next",
+				"    }",
+				"}",
+				"next 100",
+			)
+			Expect(tm.generation).To(Equal(uint64(109)))
+		})
+		It("sees variables change in a loop", func() {
+			run("repeat 3 {", "set last $generation", "next", "}")
+			Expect(tm.generation).To(Equal(uint64(3)))
+			Expect(tm.variables["last"]).To(Equal("2"))
+		})
+		It("doesn't run a block that's missing its }", func() {
+			Expect(run("repeat 3 {", "next", "repeat 2 {", "next", "}")).To(BeTrue())
+			Expect(tm.generation).To(Equal(uint64(0)))
+			Expect(shown.shown).To(ContainElement("Missing } at the end of the block"))
+		})
+		It("stops when a command in a loop quits", func() {
+			Expect(run("repeat 10 {", "next", "quit", "}", "next")).To(BeFalse())
+			Expect(tm.generation).To(Equal(uint64(1)))
+		})
+		It("undoes everything a line ran at once", func() {
+			session := "alive 0 0\nrepeat 3 {\n  alive $generation 5\n  next\n}\nrepeat 4 next\nundo\n"
+			tm = NewTextManager(hashlife.NewHashLifeBoard(), strings.NewReader(session), shown, 16, Options{}).(*textManager)
+			tm.Manage()
+			Expect(tm.generation).To(Equal(uint64(3)))
+			Expect(tm.undone).To(HaveLen(2))
+			Expect(tm.redoable).To(HaveLen(1))
+		})
+		It("ignores comments and blank lines", func() {
+			before := len(shown.shown)
+			Expect(run("# just a comment", "", "   ")).To(BeTrue())
+			Expect(shown.shown).To(HaveLen(before))
+		})
+	})
+
+	Describe("source", func() {
+		var dir string
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "scripts")
+			Expect(err).NotTo(HaveOccurred())
+		})
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("runs the commands in a file", func() {
+			script := filepath.Join(dir, "script.txt")
+			Expect(ioutil.WriteFile(script, []byte("alive 0 0\nrepeat 2 {\n  next\n}\n"), 0644)).To(Succeed())
+			Expect(run("source " + script)).To(BeTrue())
+			Expect(tm.generation).To(Equal(uint64(2)))
+		})
+		It("stops scripts that source themselves", func() {
+			script := filepath.Join(dir, "loop.txt")
+			Expect(ioutil.WriteFile(script, []byte("next\nsource "+script+"\n"), 0644)).To(Succeed())
+			Expect(run("source " + script)).To(BeTrue())
+			Expect(tm.generation).To(Equal(uint64(maxSourceDepth)))
+			Expect(tm.sourceDepth).To(Equal(0))
+		})
+		It("complains about files that aren't there", func() {
+			Expect(run("source " + filepath.Join(dir, "missing.txt"))).To(BeTrue())
+			Expect(shown.shown[len(shown.shown)-1]).To(HavePrefix("Could not read the script"))
+		})
+	})
+
+	Describe("Manage", func() {
+		It("stops at the end of the input", func() {
+			tm.Reader.Reset(strings.NewReader("next\nrepeat 2 {\nnext"))
+			tm.Manage()
+			Expect(tm.generation).To(Equal(uint64(1)))
+			Expect(shown.shown[len(shown.shown)-1]).To(Equal("Bye!"))
+		})
+	})
+})
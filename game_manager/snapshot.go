@@ -0,0 +1,213 @@
+package game_manager
+
+import (
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/display"
+	"github.com/mitchellgordon95/ConwaysGOL/files"
+	"sort"
+)
+
+// A saved board, along with how far it had been run and how it was being viewed
+type snapshot struct {
+	board                 common.GolBoard
+	generation            uint64
+	centerX, centerY      int64
+	viewWidth, viewHeight int64
+}
+
+func (tm *textManager) snapshot(tokens []string) {
+	if len(tokens) < 1 {
+		tm.ShowMessage("Not enough arguments")
+		return
+	}
+
+	switch tokens[0] {
+	case "save":
+		tm.saveSnapshot(tokens[1:])
+	case "load":
+		tm.loadSnapshot(tokens[1:])
+	case "list":
+		tm.listSnapshots()
+	case "delete":
+		if len(tokens) < 2 {
+			tm.ShowMessage("Not enough arguments")
+			return
+		}
+		if _, ok := tm.snapshots[tokens[1]]; !ok {
+			tm.ShowMessage("No snapshot named " + tokens[1])
+			return
+		}
+		delete(tm.snapshots, tokens[1])
+		tm.ShowMessage("Deleted snapshot " + tokens[1])
+	default:
+		tm.ShowMessage("Expected snapshot save, load, list or delete")
+	}
+}
+
+// Saves the board and view under a name, and to a file if one is given
+func (tm *textManager) saveSnapshot(tokens []string) {
+	if len(tokens) < 1 {
+		tm.ShowMessage("Not enough arguments")
+		return
+	}
+
+	name := tokens[0]
+	snap := snapshot{tm.board, tm.generation, tm.centerX, tm.centerY, tm.viewWidth, tm.viewHeight}
+	tm.snapshots[name] = snap
+
+	if len(tokens) > 1 {
+		err := files.SaveSnapshot(snap.board, tokens[1], files.JsonSnapshot{
+			Generation: snap.generation,
+			CenterX:    snap.centerX,
+			CenterY:    snap.centerY,
+			ViewWidth:  snap.viewWidth,
+			ViewHeight: snap.viewHeight,
+		})
+		if err != nil {
+			tm.ShowMessage("Saved snapshot " + name + ", but could not save it to a file: " + err.Error())
+			return
+		}
+		tm.ShowMessage("Saved snapshot " + name + " to " + tokens[1])
+		return
+	}
+	tm.ShowMessage("Saved snapshot " + name)
+}
+
+// Puts the board and view back the way they were in a snapshot. If a file is given, the snapshot is read from it first.
+func (tm *textManager) loadSnapshot(tokens []string) {
+	if len(tokens) < 1 {
+		tm.ShowMessage("Not enough arguments")
+		return
+	}
+
+	name := tokens[0]
+	if len(tokens) > 1 {
+		board, saved, err := files.LoadSnapshot(tm.board.Clear(), tokens[1])
+		if err != nil {
+			tm.ShowMessage("Could not load snapshot: " + err.Error())
+			return
+		}
+		snap := snapshot{board, saved.Generation, saved.CenterX, saved.CenterY, saved.ViewWidth, saved.ViewHeight}
+		// Files saved as plain boards don't have a view
+		if snap.viewWidth < 1 || snap.viewHeight < 1 {
+			snap.viewWidth, snap.viewHeight = tm.viewWidth, tm.viewHeight
+		}
+		tm.snapshots[name] = snap
+	}
+
+	snap, ok := tm.snapshots[name]
+	if !ok {
+		tm.ShowMessage("No snapshot named " + name)
+		return
+	}
+
+	tm.generation = snap.generation
+	tm.centerX, tm.centerY = snap.centerX, snap.centerY
+	tm.viewWidth, tm.viewHeight = snap.viewWidth, snap.viewHeight
+	tm.setBoard(snap.board)
+	// Following something takes over from the snapshot's view, the same as when the board runs
+	tm.recenter()
+	tm.showBoard()
+	tm.ShowMessage("Loaded snapshot " + name)
+}
+
+func (tm *textManager) listSnapshots() {
+	if len(tm.snapshots) == 0 {
+		tm.ShowMessage("No snapshots")
+		return
+	}
+
+	names := make([]string, 0, len(tm.snapshots))
+	for name := range tm.snapshots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		snap := tm.snapshots[name]
+		tm.ShowMessage(fmt.Sprintf("%s: generation %d, %d cells, viewing %dx%d at (%d,%d)",
+			name, snap.generation, snap.board.Population(), snap.viewWidth, snap.viewHeight, snap.centerX, snap.centerY))
+	}
+}
+
+// Saves the alive cells on the board to a json file, with respect to the current center, so load can read them back in
+func (tm *textManager) save(tokens []string) {
+	if len(tokens) < 1 {
+		tm.ShowMessage("Not enough arguments")
+		return
+	}
+	if err := files.SaveJson(tm.board, tokens[0], tm.centerX, tm.centerY); err != nil {
+		tm.ShowMessage("Could not save board: " + err.Error())
+		return
+	}
+	tm.ShowMessage("Saved board to " + tokens[0])
+}
+
+// The most born and died cells to list by coordinates
+const maxDiffCells = 10
+
+// Shows the cells born and died between two snapshots, or between a snapshot and the board
+func (tm *textManager) diff(tokens []string) {
+	if len(tokens) < 1 {
+		tm.ShowMessage("Not enough arguments")
+		return
+	}
+
+	before, ok := tm.snapshots[tokens[0]]
+	if !ok {
+		tm.ShowMessage("No snapshot named " + tokens[0])
+		return
+	}
+	after := snapshot{board: tm.board, generation: tm.generation}
+	afterName := "now"
+	if len(tokens) > 1 {
+		after, ok = tm.snapshots[tokens[1]]
+		if !ok {
+			tm.ShowMessage("No snapshot named " + tokens[1])
+			return
+		}
+		afterName = tokens[1]
+	}
+
+	born := after.board.Subtract(before.board)
+	died := before.board.Subtract(after.board)
+
+	// Show the later board with the cells that changed colored, the way the color command shows a single step
+	if colorer, ok := tm.Displayer.(display.Colorer); ok {
+		changes := common.NewCellHistory(before.board, 1)
+		changes.Advance(after.board)
+
+		mode := tm.colorMode
+		if mode == display.NoColor {
+			mode = display.Color256
+		}
+		colorer.SetColors(mode, changes)
+		min_x, min_y, max_x, max_y := tm.viewBounds()
+		tm.Display(after.board, min_x, min_y, max_x, max_y)
+		colorer.SetColors(tm.colorMode, tm.history)
+	}
+
+	tm.ShowMessage(fmt.Sprintf("%d cells born and %d cells died from %s (generation %d) to %s (generation %d)",
+		born.Population(), died.Population(), tokens[0], before.generation, afterName, after.generation))
+	tm.showCells("Born", born)
+	tm.showCells("Died", died)
+}
+
+// Lists the alive cells on a board, or just where they are if there are too many
+func (tm *textManager) showCells(label string, board common.GolBoard) {
+	if board.Population() == 0 {
+		return
+	}
+	if board.Population() > maxDiffCells {
+		min_x, min_y, max_x, max_y, _ := board.BoundingBox()
+		tm.ShowMessage(fmt.Sprintf("%s: in (%d,%d) to (%d,%d)", label, min_x, min_y, max_x, max_y))
+		return
+	}
+
+	msg := label + ":"
+	board.ForEachAlive(func(x, y int64) {
+		msg += fmt.Sprintf(" (%d,%d)", x, y)
+	})
+	tm.ShowMessage(msg)
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"github.com/mitchellgordon95/ConwaysGOL/common"
 	"github.com/mitchellgordon95/ConwaysGOL/display"
-	"github.com/mitchellgordon95/ConwaysGOL/files"
+	"github.com/mitchellgordon95/ConwaysGOL/library"
+	"github.com/mitchellgordon95/ConwaysGOL/pattern"
+	"github.com/mitchellgordon95/ConwaysGOL/soup"
 	"io"
 	"strconv"
 	"strings"
@@ -19,80 +21,201 @@ type textManager struct {
 	display.Displayer
 	viewWidth, viewHeight int64
 	centerX, centerY      int64
+	// History of the cells on the board, kept while the display is colored, and how they're colored
+	history   *common.CellHistory
+	colorMode display.ColorMode
+	// The number of generations the board has been stepped forward
+	generation uint64
+	// The population of the board over the latest generations
+	population *common.PopulationHistory
+	// The states of the board before each line the user entered that changed it, and the ones undone since, with the latest last
+	undone, redoable []edit
+	// The most states to remember for undo
+	undoLimit int
+	// Boards and views saved by name
+	snapshots map[string]snapshot
+	// Boards saved along the run, to go back to earlier generations
+	checkpoints *checkpointSet
+	// The region selected for copy and cut, or nil if there isn't one
+	selection *selection
+	// The region last copied or cut, or nil if there isn't one
+	clipboard *pattern.Region
+	// Patterns loaded into named layers
+	layers map[string]layer
+	// Well known patterns that can be loaded by name
+	lib *library.Library
+	// Where a pattern was last found, or nil if nothing has been looked for
+	found *findResults
+	// What the view follows as the board runs, or nil if it stays put
+	following *follower
+	// Variables set with set, to use in commands
+	variables map[string]string
+	// How many scripts deep the commands being run are
+	sourceDepth int
+	// The script to run before reading commands from the user, or empty for none
+	script string
 }
 
 /*
 Creates a new text manager to manage the game state.
 Takes a game board, a reader to get text from the user, a game displayer,
-and the width of the game board to display, centered at 0. By default, the view is a square.
+the width of the game board to display, centered at 0, and the options from the command line. By default, the view is a square.
 */
-func NewTextManager(board common.GolBoard, read io.Reader, displayer display.Displayer, width int64) GolManager {
-	return &textManager{board, bufio.NewReader(read), displayer, width, width, 0, 0}
+func NewTextManager(board common.GolBoard, read io.Reader, displayer display.Displayer, width int64, opts Options) GolManager {
+	tm := &textManager{
+		board:       board,
+		Reader:      bufio.NewReader(read),
+		Displayer:   displayer,
+		viewWidth:   width,
+		viewHeight:  width,
+		population:  common.NewPopulationHistory(populationHistorySize),
+		undoLimit:   defaultUndoLimit,
+		snapshots:   map[string]snapshot{},
+		layers:      map[string]layer{},
+		lib:         library.New(),
+		variables:   map[string]string{},
+		checkpoints: newCheckpointSet(),
+		script:      opts.Script,
+	}
+	tm.population.Record(0, board)
+	tm.checkpoints.edit(0, board)
+	if opts.LibraryDir != "" {
+		if err := tm.lib.AddDir(opts.LibraryDir); err != nil {
+			displayer.ShowMessage("Could not read the pattern library: " + err.Error())
+		}
+	}
+	return tm
 }
 
 func (tm *textManager) Manage() {
 	tm.greet()
 
+	if tm.script != "" && !tm.undoable("source "+tm.script, func() bool { return tm.source([]string{tm.script}) }) {
+		return
+	}
 	for {
 		text, err := tm.ReadString('\n')
-		if err != nil {
+		if err != nil && err != io.EOF {
 			tm.ShowMessage("Oops! Something went wrong. " + err.Error())
+			tm.stopRecording()
+			return
 		}
-		text = strings.TrimSpace(text)
-		tokens := strings.Split(text, " ")
-
-		if len(tokens) == 0 {
-			continue
+		if !tm.enter(text, tm.readLine) {
+			return
 		}
-
-		switch tokens[0] {
-		case "show":
-			tm.showBoard()
-		case "load":
-			tm.load(tokens[1:])
-		case "quit":
-			tm.ShowMessage("Bye!")
+		if err == io.EOF {
+			// There's nothing more to read, like at the end of a file piped in
+			tm.quit()
 			return
-		case "alive":
-			tm.aliveCell(tokens[1:])
-		case "kill":
-			tm.deadCell(tokens[1:])
-		case "clear":
-			tm.board = tm.board.Clear()
-			tm.showBoard()
-			tm.ShowMessage("Cleared the board!")
-		case "next":
-			tm.nextBoard(tokens[1:])
-		case "center":
-			tm.center(tokens[1:])
-		case "resize":
-			tm.resize(tokens[1:])
-		case "help":
-			tm.help()
-		case "animate":
-			tm.animate(tokens[1:])
-		default:
-			tm.ShowMessage("Invalid command.")
 		}
 	}
+}
+
+// Reads the next line the user types
+func (tm *textManager) readLine() (string, error) {
+	return tm.ReadString('\n')
+}
+
+// Runs a single command. Returns false if the user quit.
+func (tm *textManager) run(text string) bool {
+	tokens := strings.Split(text, " ")
+
+	switch tokens[0] {
+	case "show":
+		tm.showBoard()
+	case "load":
+		tm.load(tokens[1:])
+	case "quit":
+		tm.quit()
+		return false
+	case "alive":
+		tm.aliveCell(tokens[1:])
+	case "kill":
+		tm.deadCell(tokens[1:])
+	case "clear":
+		tm.setBoard(tm.board.Clear())
+		tm.showBoard()
+		tm.ShowMessage("Cleared the board!")
+	case "next":
+		tm.nextBoard(tokens[1:])
+	case "center":
+		tm.center(tokens[1:])
+	case "resize":
+		tm.resize(tokens[1:])
+	case "help":
+		tm.help()
+	case "animate":
+		tm.animate(tokens[1:])
+	case "color":
+		tm.color(tokens[1:])
+	case "grid":
+		tm.grid(tokens[1:])
+	case "export":
+		tm.export(tokens[1:])
+	case "analyze":
+		tm.analyze(tokens[1:])
+	case "census":
+		tm.census(tokens[1:])
+	case "lifespan":
+		tm.lifespan(tokens[1:])
+	case "random":
+		tm.random(tokens[1:])
+	case "record":
+		tm.record(tokens[1:])
+	case "plot":
+		tm.plot(tokens[1:])
+	case "undo":
+		tm.undo(tokens[1:])
+	case "redo":
+		tm.redo(tokens[1:])
+	case "history":
+		tm.showHistory(tokens[1:])
+	case "snapshot":
+		tm.snapshot(tokens[1:])
+	case "save":
+		tm.save(tokens[1:])
+	case "goto":
+		tm.gotoGeneration(tokens[1:])
+	case "select":
+		tm.selectRegion(tokens[1:])
+	case "copy":
+		tm.copyRegion(false)
+	case "cut":
+		tm.copyRegion(true)
+	case "paste":
+		tm.paste(tokens[1:])
+	case "diff":
+		tm.diff(tokens[1:])
+	case "layer":
+		tm.layer(tokens[1:])
+	case "library":
+		tm.library(tokens[1:])
+	case "find":
+		tm.find(tokens[1:])
+	case "follow":
+		tm.follow(tokens[1:])
+	default:
+		tm.ShowMessage("Invalid command.")
+	}
+
+	return true
+}
 
+func (tm *textManager) quit() {
+	tm.ShowMessage("Bye!")
+	tm.stopRecording()
 }
 
 func (tm *textManager) showBoard() {
-	half_width := tm.viewWidth / 2
-	half_height := tm.viewHeight / 2
-	tm.Display(tm.board, tm.centerX-half_width, tm.centerY-half_height, tm.centerX+half_width, tm.centerY+half_height)
+	min_x, min_y, max_x, max_y := tm.viewBounds()
+	tm.Display(tm.board, min_x, min_y, max_x, max_y)
 }
 
-func (tm *textManager) load(tokens []string) {
-	newBoard, err := files.LoadJson(tm.board, tokens[0], tm.centerX, tm.centerY)
-	if err != nil {
-		tm.ShowMessage("Could not load board: " + err.Error())
-		return
-	}
-	tm.board = newBoard
-	tm.showBoard()
-	tm.ShowMessage("Loaded file onto board.")
+// Returns the region of the board in view, from min coordinates (inclusive) to max coordinates (exclusive)
+func (tm *textManager) viewBounds() (min_x, min_y, max_x, max_y int64) {
+	half_width := tm.viewWidth / 2
+	half_height := tm.viewHeight / 2
+	return tm.centerX - half_width, tm.centerY - half_height, tm.centerX + half_width, tm.centerY + half_height
 }
 
 func (tm *textManager) center(tokens []string) {
@@ -150,7 +273,7 @@ func (tm *textManager) aliveCell(tokens []string) {
 		tm.ShowMessage(err.Error())
 		return
 	}
-	tm.board = tm.board.AddCell(x, y)
+	tm.setBoard(tm.board.AddCell(x, y))
 	tm.showBoard()
 	tm.ShowMessage("Set cell to alive!")
 }
@@ -164,27 +287,83 @@ func (tm *textManager) deadCell(tokens []string) {
 		tm.ShowMessage(err.Error())
 		return
 	}
-	tm.board = tm.board.KillCell(x, y)
+	tm.setBoard(tm.board.KillCell(x, y))
 	tm.showBoard()
 	tm.ShowMessage("Set cell to be dead!")
 }
 
 func (tm *textManager) nextBoard(tokens []string) {
 	if len(tokens) == 0 {
-		tm.board = tm.board.Step()
+		tm.step()
 	} else {
 		steps, err := strconv.ParseUint(tokens[0], 10, 64)
 		if err != nil {
 			tm.ShowMessage("Invalid number of steps")
 			return
 		}
-		for i := uint64(0); i < steps; i++ {
-			tm.board = tm.board.Step()
-		}
+		tm.stepN(steps)
 	}
+	tm.recenter()
 	tm.showBoard()
 }
 
+// Replaces the board with an edited version of it
+func (tm *textManager) setBoard(board common.GolBoard) {
+	tm.board = board
+	if tm.history != nil {
+		tm.history.Sync(board)
+	}
+	tm.population.Record(tm.generation, board)
+	tm.checkpoints.edit(tm.generation, board)
+}
+
+// Moves the board forward one generation
+func (tm *textManager) step() {
+	tm.board = tm.board.Step()
+	tm.generation++
+	if tm.history != nil {
+		tm.history.Advance(tm.board)
+	}
+	tm.population.Record(tm.generation, tm.board)
+	tm.checkpoints.stepped(tm.generation-1, tm.generation, tm.board)
+}
+
+// The most times to stop and sample the population while moving the board forward many generations at once
+const maxStepSamples = 128
+
+// Moves the board forward n generations
+func (tm *textManager) stepN(n uint64) {
+	if tm.history != nil || n <= maxStepSamples {
+		// The cell history has to see every generation
+		for i := uint64(0); i < n; i++ {
+			tm.step()
+		}
+		return
+	}
+
+	// Jump most of the way with hashlife, stopping evenly along the way so the population history has something to plot
+	stride, extra := n/maxStepSamples, n%maxStepSamples
+	for i := uint64(0); i < maxStepSamples; i++ {
+		if i < extra {
+			tm.leap(stride + 1)
+		} else {
+			tm.leap(stride)
+		}
+	}
+}
+
+// Moves the board forward n generations in one go with hashlife
+func (tm *textManager) leap(n uint64) {
+	from := tm.generation
+	tm.board = tm.board.StepN(n)
+	tm.generation += n
+	if tm.history != nil {
+		tm.history.Sync(tm.board)
+	}
+	tm.population.Record(tm.generation, tm.board)
+	tm.checkpoints.stepped(from, tm.generation, tm.board)
+}
+
 func parseCoordinates(tokens []string) (int64, int64, error) {
 	x, err := strconv.ParseInt(tokens[0], 10, 64)
 	if err != nil {
@@ -216,11 +395,214 @@ func (tm *textManager) animate(tokens []string) {
 	tm.showBoard()
 	for i := uint64(0); i < steps; i++ {
 		time.Sleep(time.Duration(delay) * time.Millisecond)
-		tm.board = tm.board.Step()
+		tm.step()
+		tm.recenter()
 		tm.showBoard()
 	}
 }
 
+// The number of generations to show the trail of dead cells for, unless the user says otherwise
+const defaultTrail = 8
+
+func (tm *textManager) color(tokens []string) {
+	colorer, ok := tm.Displayer.(display.Colorer)
+	if !ok {
+		tm.ShowMessage("This display can't show colors")
+		return
+	}
+	if len(tokens) < 1 {
+		tm.ShowMessage("Not enough arguments")
+		return
+	}
+
+	mode, err := display.ParseColorMode(tokens[0])
+	if err != nil {
+		tm.ShowMessage(err.Error())
+		return
+	}
+
+	trail := uint64(defaultTrail)
+	if len(tokens) > 1 {
+		trail, err = strconv.ParseUint(tokens[1], 10, 64)
+		if err != nil {
+			tm.ShowMessage("Invalid trail length")
+			return
+		}
+	}
+
+	if mode == display.NoColor {
+		tm.history = nil
+	} else if tm.history == nil {
+		tm.history = common.NewCellHistory(tm.board, trail)
+	} else {
+		tm.history.Trail = trail
+	}
+	tm.colorMode = mode
+	colorer.SetColors(mode, tm.history)
+
+	tm.showBoard()
+	tm.ShowMessage("Updated colors")
+}
+
+// The spacing of gridlines when they're turned on without saying how far apart they should be
+const defaultGridSpacing = 10
+
+func (tm *textManager) grid(tokens []string) {
+	overlayer, ok := tm.Displayer.(display.Overlayer)
+	if !ok {
+		tm.ShowMessage("This display can't show a grid")
+		return
+	}
+	overlay := overlayer.Overlay()
+	gridOn := display.Overlay{Axes: true, GridSpacing: defaultGridSpacing, Rulers: true}
+
+	if len(tokens) == 0 {
+		// Toggle everything on or off. The axes are on to begin with, so only the gridlines and rulers say which.
+		if overlay.GridSpacing == 0 && !overlay.Rulers {
+			overlay = gridOn
+		} else {
+			overlay = display.Overlay{}
+		}
+		tokens = []string{""}
+	}
+
+	switch tokens[0] {
+	case "":
+	case "on":
+		overlay = gridOn
+		if len(tokens) > 1 {
+			spacing, err := strconv.ParseInt(tokens[1], 10, 64)
+			if err != nil || spacing < 0 {
+				tm.ShowMessage("Invalid grid spacing")
+				return
+			}
+			overlay.GridSpacing = spacing
+		}
+	case "off":
+		overlay = display.Overlay{}
+	case "axes", "rulers":
+		if len(tokens) < 2 || (tokens[1] != "on" && tokens[1] != "off") {
+			tm.ShowMessage("Expected on or off")
+			return
+		}
+		if tokens[0] == "axes" {
+			overlay.Axes = tokens[1] == "on"
+		} else {
+			overlay.Rulers = tokens[1] == "on"
+		}
+	case "lines":
+		if len(tokens) < 2 {
+			tm.ShowMessage("Not enough arguments")
+			return
+		}
+		spacing, err := strconv.ParseInt(tokens[1], 10, 64)
+		if err != nil || spacing < 0 {
+			tm.ShowMessage("Invalid grid spacing")
+			return
+		}
+		overlay.GridSpacing = spacing
+	default:
+		tm.ShowMessage("Invalid grid option")
+		return
+	}
+
+	overlayer.SetOverlay(overlay)
+	tm.showBoard()
+	tm.ShowMessage("Updated grid")
+}
+
+// Adds a random soup to the board, centered on the view. Takes the width, height, density and seed in that order,
+// with a symmetry like D4 anywhere among them.
+func (tm *textManager) random(tokens []string) {
+	s := soup.Soup{Width: 16, Height: 16, Density: 0.5, Symmetry: soup.C1, Seed: soup.NewSeed()}
+
+	var numbers []string
+	for _, token := range tokens {
+		if symmetry, err := soup.ParseSymmetry(token); err == nil {
+			s.Symmetry = symmetry
+		} else {
+			numbers = append(numbers, token)
+		}
+	}
+
+	var err error
+	for i, token := range numbers {
+		switch i {
+		case 0:
+			s.Width, err = strconv.ParseInt(token, 10, 64)
+			// The height defaults to the width
+			s.Height = s.Width
+		case 1:
+			s.Height, err = strconv.ParseInt(token, 10, 64)
+		case 2:
+			s.Density, err = strconv.ParseFloat(token, 64)
+		case 3:
+			s.Seed, err = strconv.ParseInt(token, 10, 64)
+		}
+		if err != nil {
+			tm.ShowMessage("Invalid argument " + token)
+			return
+		}
+	}
+
+	board, err := s.Place(tm.board, tm.centerX-s.Width/2, tm.centerY-s.Height/2)
+	if err != nil {
+		tm.ShowMessage(err.Error())
+		return
+	}
+	tm.setBoard(board)
+	tm.showBoard()
+	tm.ShowMessage("Added a " + s.String())
+}
+
+func (tm *textManager) record(tokens []string) {
+	recorder, ok := tm.Displayer.(display.Recorder)
+	if !ok {
+		tm.ShowMessage("This display can't be recorded")
+		return
+	}
+	if len(tokens) < 1 {
+		tm.ShowMessage("Not enough arguments")
+		return
+	}
+
+	switch tokens[0] {
+	case "start":
+		if len(tokens) < 2 {
+			tm.ShowMessage("Expected the file to record to")
+			return
+		}
+		if err := recorder.StartRecording(tokens[1]); err != nil {
+			tm.ShowMessage("Could not start recording: " + err.Error())
+			return
+		}
+		// Start the recording off with the board, so it doesn't begin with a blank screen
+		tm.showBoard()
+		tm.ShowMessage("Recording to " + tokens[1])
+	case "stop":
+		if !recorder.Recording() {
+			tm.ShowMessage("Not recording")
+			return
+		}
+		tm.stopRecording()
+	default:
+		tm.ShowMessage("Expected start or stop")
+	}
+}
+
+// Finishes the recording, if there is one
+func (tm *textManager) stopRecording() {
+	recorder, ok := tm.Displayer.(display.Recorder)
+	if !ok || !recorder.Recording() {
+		return
+	}
+	if err := recorder.StopRecording(); err != nil {
+		tm.ShowMessage("Could not finish the recording: " + err.Error())
+		return
+	}
+	tm.ShowMessage("Stopped recording")
+}
+
 func (tm *textManager) greet() {
 	tm.ShowMessage("Welcome to Conway's Game of Life!")
 	tm.ShowMessage("Enter \"help\" to show possible commands")
@@ -228,7 +610,15 @@ func (tm *textManager) greet() {
 
 func (tm *textManager) help() {
 	tm.ShowMessage("Enter \"show\" to show the current game board")
-	tm.ShowMessage("Enter \"load [filename]\" to load a json file containing alive cells onto the board, with respect to the current center")
+	tm.ShowMessage("Enter \"load [filename] [options]\" to load a json file containing alive cells onto the board, with respect to the current center. Options look like name=value:")
+	tm.ShowMessage("    offset=[dx],[dy] to move the pattern, rotate=[degrees] to turn it counterclockwise (0, 90, 180 or 270), flip=on to mirror it left to right first, advance=[generations] to run it before adding it, and layer=[name] to put it in a layer")
+	tm.ShowMessage("Enter \"library list [category]\" to list the patterns in the library, like still lifes, oscillators, spaceships, guns and methuselahs, and \"library search [text]\" to search it")
+	tm.ShowMessage("Enter \"library load [name] [options]\" to load a pattern from the library, with the same options as load, and \"library dir [directory]\" to add the json files in a directory to the library")
+	tm.ShowMessage("Enter \"find [pattern] [options]\" to list every copy of a json file, library pattern or the clipboard on the board, in any orientation. Options look like name=value: border=on|off to only find copies with dead cells all around them (on by default), center=on|off to center the view on the first one")
+	tm.ShowMessage("Enter \"find next\" to center the view on the next copy found")
+	tm.ShowMessage("Enter \"follow all\" to keep the view on the middle of the pattern as it runs, or goes to another generation with goto, undo or redo, \"follow [x] [y]\" to keep it on the object nearest (x,y), like a spaceship, and \"follow off\" to stop")
+	tm.ShowMessage("Enter \"layer list\" to list the layers on the board, and \"layer remove [name]\" to take one off the board. Loading into a layer that's already there replaces it")
+	tm.ShowMessage("Enter \"save [filename]\" to save the alive cells on the board to a json file, with respect to the current center, that load can read back in")
 	tm.ShowMessage("Enter \"next\" to go to the next step in the simulation")
 	tm.ShowMessage("Enter \"next [steps]\" to do a certain number of steps in the simulation")
 	tm.ShowMessage("Enter \"alive [x] [y]\" to set the cell at (x,y) as alive")
@@ -238,6 +628,35 @@ func (tm *textManager) help() {
 	tm.ShowMessage("Enter \"resize [size]\" to change the size of the view to a square with the specified width")
 	tm.ShowMessage("Enter \"resize [width] [height]\" to change the size of the view to the specified width and height")
 	tm.ShowMessage("Enter \"animate [steps] [delay]\" to animate the board for a certain number of steps. Delay is in milliseconds. Press enter at any time to stop the animation.")
+	tm.ShowMessage("Enter \"color [off|256|truecolor] [trail]\" to color cells by age, highlighting cells born (green) and died (red) this generation, with a trail of cells that died in the last [trail] generations")
+	tm.ShowMessage("Enter \"grid\" to toggle the axes, gridlines and coordinate rulers on and off")
+	tm.ShowMessage("Enter \"grid on [spacing]\" to show the axes, coordinate rulers, and gridlines every [spacing] cells, and \"grid off\" to hide them all")
+	tm.ShowMessage("Enter \"grid axes [on|off]\", \"grid rulers [on|off]\" or \"grid lines [spacing]\" to change a single overlay. A spacing of 0 hides the gridlines")
+	tm.ShowMessage("Enter \"export png [filename] [options]\" to save an image of the board. Options look like name=value:")
+	tm.ShowMessage("    region=view|all|x1,y1,x2,y2 (defaults to the view), cell=[pixels], grid=on|off, zoom=[level] to show 2^level by 2^level blocks of cells per pixel, alive=#rrggbb, dead=#rrggbb, gridcolor=#rrggbb")
+	tm.ShowMessage("Enter \"export svg [filename] [options]\" to save the board as an svg. Takes the png options (except zoom), labels=on to label coordinates along the edges, and crop=on to crop the region to the alive cells in it")
+	tm.ShowMessage("Enter \"export gif [filename] [options]\" to save an animation of the board as it runs, without changing the board, or \"export frames [filename] [options]\" to save each frame as a numbered png.")
+	tm.ShowMessage("    Along with the png options, animations take frames=[count], step=[generations] or jump=[k] to step 2^k generations between frames, delay=[hundredths of a second], region=fit to fit the region to the pattern, and track=on to follow the pattern as it moves")
+	tm.ShowMessage("Enter \"analyze [options]\" to find out whether the board settles into a cycle, and its period. Takes max=[generations] to give up after (default 10000), and moving=off to only look for patterns that stay in place")
+	tm.ShowMessage("Enter \"random [width] [height] [density] [seed]\" to add a random soup centered on the view (by default 16x16, with density 0.5 and a new seed each time). Add C2, C4, D2, D4 or D8 to make it symmetric")
+	tm.ShowMessage("Enter \"census [distance]\" to count the objects on the board, like blocks, blinkers and gliders. Cells at most [distance] apart (default 1, at most 8) count as part of the same object")
+	tm.ShowMessage("Enter \"lifespan [generations]\" to find the generation the board stabilizes at, ignoring gliders and other spaceships flying away, and list the spaceships it sends out. Gives up after [generations] (default 10000)")
+	tm.ShowMessage("Enter \"plot [options]\" to chart the population over the latest generations. Options are width=[columns], height=[rows], last=[generations] to only chart the latest ones, and style=unicode|ascii|spark")
+	tm.ShowMessage("Enter \"export csv [filename]\" to save the generation, population and bounding box of the board over the latest generations as a csv file")
+	tm.ShowMessage("Enter \"snapshot save [name] [filename]\" to remember the board, generation and view under [name], and to save them to a json file if [filename] is given")
+	tm.ShowMessage("Enter \"snapshot load [name] [filename]\" to go back to a snapshot, reading it from [filename] if given, \"snapshot list\" to list them, and \"snapshot delete [name]\" to forget one")
+	tm.ShowMessage("Enter \"goto [generation]\" to run the board forward or back to [generation], counting from when it was started, and \"goto\" to show the current generation")
+	tm.ShowMessage("Enter \"diff [name] [other name]\" to show the cells born and died between two snapshots, or between a snapshot and the board if only one is given")
+	tm.ShowMessage("Enter \"select [x1] [y1] [x2] [y2]\" to select the rectangle with corners (x1,y1) and (x2,y2), and then \"copy\" or \"cut\" to copy it to the clipboard")
+	tm.ShowMessage("Enter \"paste [x] [y] [rotation] [flip] [or|xor|copy]\" to paste the clipboard with its lower left corner at (x,y), turned [rotation] degrees counterclockwise (0, 90, 180 or 270) and mirrored if flip is given.")
+	tm.ShowMessage("    or adds the cells to the board (the default), xor flips the cells under them, and copy replaces everything under the pasted rectangle")
+	tm.ShowMessage("Enter \"undo [count]\" to undo the last [count] commands that changed the board (default 1), and \"redo [count]\" to redo them. A repeat or source is undone all at once")
+	tm.ShowMessage("Enter \"history\" to list the commands that can be undone and redone, and \"history limit [count]\" to change how many are remembered (default 100)")
+	tm.ShowMessage("Enter \"record start [filename]\" to record everything shown from now on as an asciinema cast, and \"record stop\" to finish it. Play it back with \"asciinema play [filename]\"")
+	tm.ShowMessage("Enter \"source [filename]\" to run the commands in a file, one per line, like the --script option does at startup. A word starting with # starts a comment that runs to the end of the line, so colors like alive=#ff0000 still work")
+	tm.ShowMessage("Enter \"set [name] [value]\" to set a variable, which $name or ${name} is replaced with in later commands, \"unset [name]\" to forget it, and \"set\" to list them. $generation and $population are always set")
+	tm.ShowMessage("Enter \"repeat [count] [command]\" to run a command [count] times, or \"repeat [count] {\" to run the lines up to a line with just \"}\" [count] times")
+	tm.ShowMessage("In the terminal UI (--tui), click or drag with the left mouse button to bring cells to life, and with the right button to kill them")
 	tm.ShowMessage("Enter \"help\" to show this message")
 	tm.ShowMessage("Enter \"quit\" to quit")
 }
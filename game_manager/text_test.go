@@ -0,0 +1,35 @@
+package game_manager
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/display"
+	"github.com/mitchellgordon95/ConwaysGOL/hashlife"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io/ioutil"
+	"strings"
+)
+
+var _ = Describe("Grid", func() {
+	var tm *textManager
+	var overlayer display.Overlayer
+	BeforeEach(func() {
+		displayer := display.NewTextDisplayer(ioutil.Discard)
+		overlayer = displayer.(display.Overlayer)
+		tm = NewTextManager(hashlife.NewHashLifeBoard(), strings.NewReader(""), displayer, 16, Options{}).(*textManager)
+	})
+
+	It("turns the grid on the first time it's toggled, and off the next", func() {
+		tm.run("grid")
+		Expect(overlayer.Overlay()).To(Equal(display.Overlay{Axes: true, GridSpacing: defaultGridSpacing, Rulers: true}))
+		tm.run("grid")
+		Expect(overlayer.Overlay()).To(Equal(display.Overlay{}))
+		tm.run("grid")
+		Expect(overlayer.Overlay().GridSpacing).To(Equal(int64(defaultGridSpacing)))
+	})
+
+	It("turns a grid with other spacing off", func() {
+		tm.run("grid lines 5")
+		tm.run("grid")
+		Expect(overlayer.Overlay()).To(Equal(display.Overlay{}))
+	})
+})
@@ -0,0 +1,88 @@
+package game_manager
+
+import (
+	"bufio"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/display"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// xterm escape sequences to turn on button event mouse tracking with SGR encoded coordinates, and to turn it back off
+const (
+	enableMouse  = "\033[?1002h\033[?1006h"
+	disableMouse = "\033[?1006l\033[?1002l"
+)
+
+/*
+Creates a text manager that runs as an interactive terminal UI.
+The terminal is switched to non-canonical mode and xterm mouse reporting is turned on, so that
+clicking or dragging with the left mouse button draws cells in the view, and the right button erases them.
+Commands can still be typed as usual.
+
+The returned function restores the terminal, and must be called once the manager is done.
+*/
+func NewTUIManager(board common.GolBoard, term *os.File, out io.Writer, displayer display.Displayer, width int64, opts Options) (GolManager, func(), error) {
+	saved, err := stty(term, "-g")
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := stty(term, "-icanon", "-echo", "min", "1"); err != nil {
+		return nil, nil, err
+	}
+	io.WriteString(out, enableMouse)
+
+	tm := NewTextManager(board, nil, displayer, width, opts).(*textManager)
+	tm.Reader = bufio.NewReader(newMouseReader(term, out, tm.mouse))
+
+	restore := func() {
+		io.WriteString(out, disableMouse)
+		stty(term, saved)
+	}
+
+	return tm, restore, nil
+}
+
+// Runs stty on the given terminal, returning its trimmed output
+func stty(term *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = term
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// Draws or erases the cell under the mouse
+func (tm *textManager) mouse(ev mouseEvent) {
+	if ev.release {
+		return
+	}
+
+	x, y, ok := tm.screenToBoard(ev.col, ev.row)
+	if !ok {
+		return
+	}
+
+	switch {
+	case ev.button == leftButton && !tm.board.IsAlive(x, y):
+		tm.setBoard(tm.board.AddCell(x, y))
+	case ev.button == rightButton && tm.board.IsAlive(x, y):
+		tm.setBoard(tm.board.KillCell(x, y))
+	default:
+		// Nothing changed, so there's no need to redraw
+		return
+	}
+
+	tm.showBoard()
+}
+
+// Maps a position on the screen (1-based, as reported by the terminal) to the cell on the board shown there.
+// Returns false if the position is outside of the view, or the displayer can't tell where it drew cells.
+func (tm *textManager) screenToBoard(col, row int) (int64, int64, bool) {
+	locator, ok := tm.Displayer.(display.Locator)
+	if !ok {
+		return 0, 0, false
+	}
+	return locator.CellAt(col, row)
+}
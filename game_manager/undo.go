@@ -0,0 +1,174 @@
+package game_manager
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/hashlife"
+	qt "github.com/mitchellgordon95/ConwaysGOL/quadtree"
+	"strconv"
+	"strings"
+)
+
+// The most states of the board to remember for undo, unless the user says otherwise
+const defaultUndoLimit = 100
+
+// The most nodes to let the cache grow to before collecting garbage
+const maxCacheSize = 1 << 22
+
+/*
+The state of the board before a command changed it. Boards share all the nodes they have in common, so keeping old ones
+around is cheap.
+*/
+type edit struct {
+	// The command that changed the board
+	command    string
+	board      common.GolBoard
+	generation uint64
+}
+
+// Remembers the state of the board before the last command, if the command changed it
+func (tm *textManager) remember(before edit) {
+	if before.board == tm.board && before.generation == tm.generation {
+		return
+	}
+	tm.undone = append(tm.undone, before)
+	if len(tm.undone) > tm.undoLimit {
+		tm.undone = tm.undone[len(tm.undone)-tm.undoLimit:]
+	}
+	tm.redoable = nil
+}
+
+/*
+Runs something the user asked for, like a line they typed, and remembers the state of the board before it so it can be
+undone in one go, however many commands it ran. Returns what it returned, which is false if the user quit.
+*/
+func (tm *textManager) undoable(command string, do func() bool) bool {
+	before := edit{command, tm.board, tm.generation}
+	running := do()
+
+	if first := strings.SplitN(command, " ", 2)[0]; first != "undo" && first != "redo" {
+		tm.remember(before)
+	}
+	tm.pruneLayers()
+	tm.collectGarbage()
+	return running
+}
+
+// Puts the board back to an earlier or later state, and keeps the view on whatever is being followed
+func (tm *textManager) restore(state edit) {
+	tm.generation = state.generation
+	tm.setBoard(state.board)
+	tm.recenter()
+}
+
+// Parses the number of commands to undo or redo, which is 1 unless the user says otherwise
+func parseCount(tokens []string) (int, bool) {
+	if len(tokens) == 0 {
+		return 1, true
+	}
+	count, err := strconv.Atoi(tokens[0])
+	return count, err == nil && count > 0
+}
+
+func (tm *textManager) undo(tokens []string) {
+	count, ok := parseCount(tokens)
+	if !ok {
+		tm.ShowMessage("Invalid number of commands to undo")
+		return
+	}
+	if len(tm.undone) == 0 {
+		tm.ShowMessage("Nothing to undo")
+		return
+	}
+
+	for i := 0; i < count && len(tm.undone) > 0; i++ {
+		state := tm.undone[len(tm.undone)-1]
+		tm.undone = tm.undone[:len(tm.undone)-1]
+		tm.redoable = append(tm.redoable, edit{state.command, tm.board, tm.generation})
+		tm.restore(state)
+		tm.showBoard()
+		tm.ShowMessage("Undid \"" + state.command + "\"")
+	}
+}
+
+func (tm *textManager) redo(tokens []string) {
+	count, ok := parseCount(tokens)
+	if !ok {
+		tm.ShowMessage("Invalid number of commands to redo")
+		return
+	}
+	if len(tm.redoable) == 0 {
+		tm.ShowMessage("Nothing to redo")
+		return
+	}
+
+	for i := 0; i < count && len(tm.redoable) > 0; i++ {
+		state := tm.redoable[len(tm.redoable)-1]
+		tm.redoable = tm.redoable[:len(tm.redoable)-1]
+		tm.undone = append(tm.undone, edit{state.command, tm.board, tm.generation})
+		tm.restore(state)
+		tm.showBoard()
+		tm.ShowMessage("Redid \"" + state.command + "\"")
+	}
+}
+
+// Lists the commands that can be undone and redone, or with "limit N", changes how many are remembered
+func (tm *textManager) showHistory(tokens []string) {
+	if len(tokens) > 0 {
+		if tokens[0] != "limit" || len(tokens) < 2 {
+			tm.ShowMessage("Expected \"history\" or \"history limit [count]\"")
+			return
+		}
+		limit, err := strconv.Atoi(tokens[1])
+		if err != nil || limit < 0 {
+			tm.ShowMessage("Invalid limit")
+			return
+		}
+		tm.undoLimit = limit
+		if len(tm.undone) > limit {
+			tm.undone = tm.undone[len(tm.undone)-limit:]
+		}
+		if len(tm.redoable) > limit {
+			tm.redoable = tm.redoable[len(tm.redoable)-limit:]
+		}
+		tm.ShowMessage("Remembering the last " + tokens[1] + " commands")
+		return
+	}
+
+	if len(tm.undone) == 0 && len(tm.redoable) == 0 {
+		tm.ShowMessage("No history")
+		return
+	}
+	for i, state := range tm.undone {
+		tm.ShowMessage("  " + strconv.Itoa(i-len(tm.undone)) + ": " + state.command + " (generation " + strconv.FormatUint(state.generation, 10) + ")")
+	}
+	tm.ShowMessage("> now (generation " + strconv.FormatUint(tm.generation, 10) + ")")
+	for i := len(tm.redoable) - 1; i >= 0; i-- {
+		tm.ShowMessage("  +" + strconv.Itoa(len(tm.redoable)-i) + ": " + tm.redoable[i].command)
+	}
+}
+
+// Returns every board that's still needed: the current one, the ones that can be undone or redone to, snapshots,
+// layers and checkpoints
+func (tm *textManager) boards() []common.GolBoard {
+	boards := append([]common.GolBoard{tm.board}, tm.checkpoints.all()...)
+	for _, snap := range tm.snapshots {
+		boards = append(boards, snap.board)
+	}
+	for _, l := range tm.layers {
+		boards = append(boards, l.board)
+	}
+	for _, state := range tm.undone {
+		boards = append(boards, state.board)
+	}
+	for _, state := range tm.redoable {
+		boards = append(boards, state.board)
+	}
+	return boards
+}
+
+// Frees up the nodes that aren't needed by any board we're keeping, once there are enough of them to be worth it
+func (tm *textManager) collectGarbage() {
+	if qt.CacheSize() > maxCacheSize {
+		hashlife.CollectGarbage(tm.boards()...)
+	}
+}
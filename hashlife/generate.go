@@ -1,28 +1,99 @@
 package hashlife
 
 import (
+	"github.com/mitchellgordon95/ConwaysGOL/common"
 	qt "github.com/mitchellgordon95/ConwaysGOL/quadtree"
+	"sync"
 )
 
-// A cache containing the results of computing the next generation for a node
-var generationCache map[qt.Node]qt.Node
+// The number of pieces the caches are split into
+const cacheShards = 64
+
+/*
+The caches of results are split into shards by the hash of the node, each with its own lock, so boards can be run from more
+than one goroutine without them all waiting on each other. The lock isn't held while computing a result, so two goroutines
+might both compute the same one, but since nodes are canonical they'll get the same answer.
+*/
+var caches [cacheShards]struct {
+	sync.RWMutex
+	// The results of computing the next generation for a node
+	generation map[qt.Node]qt.Node
+	// The results of advancing a node by a power of 2 generations
+	jump map[jump]qt.Node
+}
 
 func init() {
-	generationCache = map[qt.Node]qt.Node{}
+	for i := range caches {
+		caches[i].generation = map[qt.Node]qt.Node{}
+		caches[i].jump = map[jump]qt.Node{}
+	}
+}
+
+/*
+Empties the caches of results and of nodes, to free up memory. Existing boards keep working, but since new nodes won't be the
+same as equal nodes made before, boards made after this can't be compared with boards made before. Only the empty tree that
+boards are padded with is kept, so padding still makes canonical trees.
+*/
+func ClearCaches() {
+	for i := range caches {
+		caches[i].Lock()
+		caches[i].generation = map[qt.Node]qt.Node{}
+		caches[i].jump = map[jump]qt.Node{}
+		caches[i].Unlock()
+	}
+	qt.CollectGarbage(deadNode)
+}
+
+/*
+Frees up memory by emptying the caches of results, and the cache of nodes apart from the nodes of the given boards.
+Unlike ClearCaches, the boards that are kept can still be compared with boards made after this.
+Boards that aren't hashlife boards are ignored.
+*/
+func CollectGarbage(keep ...common.GolBoard) {
+	for i := range caches {
+		caches[i].Lock()
+		caches[i].generation = map[qt.Node]qt.Node{}
+		caches[i].jump = map[jump]qt.Node{}
+		caches[i].Unlock()
+	}
+
+	// Boards get padded with the dead node, so it has to stay in the cache too
+	roots := []qt.Node{deadNode}
+	for _, board := range keep {
+		if hl, ok := board.(hashLife); ok {
+			roots = append(roots, hl.Node)
+		}
+	}
+	qt.CollectGarbage(roots...)
 }
 
 // Returns the next generation of life for a node one level down the tree, centered at the given node
 func NextGeneration(node qt.Node) qt.Node {
 	// If we have a cached result, use that
-	cached, ok := generationCache[node]
+	cache := &caches[qt.Shard(node.Hash(), cacheShards)]
+	cache.RLock()
+	cached, ok := cache.generation[node]
+	cache.RUnlock()
 	if ok {
 		return cached
 	}
 
+	var out qt.Node
 	if node.Level() == 2 {
-		return baseCase(node)
+		out = baseCase(node)
+	} else {
+		out = nextGeneration(node)
 	}
 
+	// Store the result for future calls
+	cache.Lock()
+	cache.generation[node] = out
+	cache.Unlock()
+	return out
+}
+
+// Computes the next generation of a node above level 2
+func nextGeneration(node qt.Node) qt.Node {
 	// First, we construct 9 nodes two levels down that encompass the area we're trying to generate
 	n00 := centeredSubnode(node.NW())
 	n01 := centeredHorizontal(node.NW(), node.NE())
@@ -37,16 +108,12 @@ func NextGeneration(node qt.Node) qt.Node {
 	// Then we construct four nodes one level down out of those 9 nodes.
 	// Each of these sub nodes will have a centered sub node that makes up one quadrant
 	// of the node we're trying to compute
-	out := qt.QuadNode(
+	return qt.QuadNode(
 		NextGeneration(qt.QuadNode(n00, n01, n10, n11)),
 		NextGeneration(qt.QuadNode(n01, n02, n11, n12)),
 		NextGeneration(qt.QuadNode(n10, n11, n20, n21)),
 		NextGeneration(qt.QuadNode(n11, n12, n21, n22)),
 	)
-
-	// Store the result for future calls
-	generationCache[node] = out
-	return out
 }
 
 // For the base case of the hashlife algorithm, when the node is level 2, just run the simulation normally to find the next generation of the centered subnode
@@ -146,3 +213,70 @@ func centeredSubSubnode(node qt.Node) qt.Node {
 		node.SE().NW().NW(),
 	)
 }
+
+// A node and the power of 2 of the number of generations to advance it by
+type jump struct {
+	node qt.Node
+	j    uint
+}
+
+/*
+Returns the node one level down the tree centered at the given node, advanced 2^j generations.
+This is the full HashLife algorithm: a node at level k can be advanced up to 2^(k-2) generations,
+and larger values of j are capped to that.
+*/
+func Advance(node qt.Node, j uint) qt.Node {
+	if node.Level() == 2 {
+		return NextGeneration(node)
+	}
+	if j > node.Level()-2 {
+		j = node.Level() - 2
+	}
+
+	key := jump{node, j}
+	cache := &caches[qt.Shard(node.Hash(), cacheShards)]
+	cache.RLock()
+	cached, ok := cache.jump[key]
+	cache.RUnlock()
+	if ok {
+		return cached
+	}
+
+	nw, ne, sw, se := node.NW(), node.NE(), node.SW(), node.SE()
+
+	// Advance the 9 overlapping nodes one level down, which gives 9 nodes two levels down that tile the center of the node.
+	// Each of the 9 is advanced by up to half the generations we need (the most that's possible a level down).
+	c00 := Advance(nw, j)
+	c01 := Advance(qt.QuadNode(nw.NE(), ne.NW(), nw.SE(), ne.SW()), j)
+	c02 := Advance(ne, j)
+	c10 := Advance(qt.QuadNode(nw.SW(), nw.SE(), sw.NW(), sw.NE()), j)
+	c11 := Advance(qt.QuadNode(nw.SE(), ne.SW(), sw.NE(), se.NW()), j)
+	c12 := Advance(qt.QuadNode(ne.SW(), ne.SE(), se.NW(), se.NE()), j)
+	c20 := Advance(sw, j)
+	c21 := Advance(qt.QuadNode(sw.NE(), se.NW(), sw.SE(), se.SW()), j)
+	c22 := Advance(se, j)
+
+	var out qt.Node
+	if j < node.Level()-2 {
+		// The 9 nodes have already been advanced far enough, so put their centers together
+		out = qt.QuadNode(
+			qt.QuadNode(c00.SE(), c01.SW(), c10.NE(), c11.NW()),
+			qt.QuadNode(c01.SE(), c02.SW(), c11.NE(), c12.NW()),
+			qt.QuadNode(c10.SE(), c11.SW(), c20.NE(), c21.NW()),
+			qt.QuadNode(c11.SE(), c12.SW(), c21.NE(), c22.NW()),
+		)
+	} else {
+		// Otherwise they've been advanced half way, so put them together into 4 nodes and advance those the other half
+		out = qt.QuadNode(
+			Advance(qt.QuadNode(c00, c01, c10, c11), j),
+			Advance(qt.QuadNode(c01, c02, c11, c12), j),
+			Advance(qt.QuadNode(c10, c11, c20, c21), j),
+			Advance(qt.QuadNode(c11, c12, c21, c22), j),
+		)
+	}
+
+	cache.Lock()
+	cache.jump[key] = out
+	cache.Unlock()
+	return out
+}
@@ -28,6 +28,40 @@ var _ = Describe("NextGeneration", func() {
 
 })
 
+var _ = Describe("StepN", func() {
+	var hl common.GolBoard
+
+	BeforeEach(func() {
+		// The R-pentomino, which keeps changing for over a thousand generations
+		hl = loadBoard(NewHashLifeBoard(), [][]int64{{0, 0}, {1, 0}, {1, 1}, {1, -1}, {2, 1}})
+	})
+
+	It("matches stepping one generation at a time", func() {
+		stepped := hl
+		for i := 0; i < 77; i++ {
+			stepped = stepped.Step()
+		}
+		Expect(hl.StepN(77) == stepped).To(BeTrue())
+	})
+	It("does nothing for 0 generations", func() {
+		Expect(hl.StepN(0) == hl).To(BeTrue())
+	})
+	It("can be split up into smaller steps", func() {
+		Expect(hl.StepN(1000) == hl.StepN(512).StepN(300).StepN(188)).To(BeTrue())
+	})
+})
+
+var _ = Describe("CollectGarbage", func() {
+	It("keeps boards comparable with boards made afterwards", func() {
+		rpentomino := loadBoard(NewHashLifeBoard(), [][]int64{{0, 0}, {1, 0}, {1, 1}, {1, -1}, {2, 1}})
+		later := rpentomino.StepN(500)
+
+		CollectGarbage(rpentomino, later)
+		Expect(rpentomino.StepN(500) == later).To(BeTrue())
+		Expect(loadBoard(NewHashLifeBoard(), [][]int64{{0, 0}, {1, 0}, {1, 1}, {1, -1}, {2, 1}}) == rpentomino).To(BeTrue())
+	})
+})
+
 func loadBoard(board common.GolBoard, alive [][]int64) common.GolBoard {
 	for _, cell := range alive {
 		board = board.AddCell(cell[0], cell[1])
@@ -25,7 +25,19 @@ func (hl hashLife) AddCell(x, y int64) common.GolBoard {
 	node, err := hl.SetValue(x, y, true)
 
 	if err != nil {
-		fmt.Println("error: %s", err.Error())
+		fmt.Println("error:", err.Error())
+		return nil
+	}
+
+	return hashLife{node}
+}
+
+// Returns a copy of the board with all the given cells alive
+func (hl hashLife) AddCells(cells []common.Cell) common.GolBoard {
+	node, err := qt.SetValues(hl.Node, cells, true)
+
+	if err != nil {
+		fmt.Println("error:", err.Error())
 		return nil
 	}
 
@@ -37,7 +49,60 @@ func (hl hashLife) KillCell(x, y int64) common.GolBoard {
 	node, err := hl.SetValue(x, y, false)
 
 	if err != nil {
-		fmt.Println("error: %s", err.Error())
+		fmt.Println("error:", err.Error())
+		return nil
+	}
+
+	return hashLife{node}
+}
+
+// Returns a copy of the board with all the given cells dead
+func (hl hashLife) KillCells(cells []common.Cell) common.GolBoard {
+	node, err := qt.SetValues(hl.Node, cells, false)
+
+	if err != nil {
+		fmt.Println("error:", err.Error())
+		return nil
+	}
+
+	return hashLife{node}
+}
+
+// Returns a board with the cells alive on this board or the other one
+func (hl hashLife) Union(other common.GolBoard) common.GolBoard {
+	return hl.combine(qt.Union, other)
+}
+
+// Returns a board with the cells alive on both this board and the other one
+func (hl hashLife) Intersect(other common.GolBoard) common.GolBoard {
+	return hl.combine(qt.Intersect, other)
+}
+
+// Returns a board with the cells alive on exactly one of this board and the other one
+func (hl hashLife) Xor(other common.GolBoard) common.GolBoard {
+	return hl.combine(qt.Xor, other)
+}
+
+// Returns a board with the cells alive on this board but not the other one
+func (hl hashLife) Subtract(other common.GolBoard) common.GolBoard {
+	return hl.combine(qt.Subtract, other)
+}
+
+func (hl hashLife) combine(op func(a, b qt.Node) (qt.Node, error), other common.GolBoard) common.GolBoard {
+	otherHl, ok := other.(hashLife)
+	if !ok {
+		// Copy the cells of other kinds of boards onto a hashlife board first
+		var cells []common.Cell
+		other.ForEachAlive(func(x, y int64) {
+			cells = append(cells, common.Cell{X: x, Y: y})
+		})
+		otherHl = NewHashLifeBoard().AddCells(cells).(hashLife)
+	}
+
+	node, err := op(hl.Node, otherHl.Node)
+
+	if err != nil {
+		fmt.Println("error:", err.Error())
 		return nil
 	}
 
@@ -49,7 +114,7 @@ func (hl hashLife) IsAlive(x, y int64) bool {
 	val, err := hl.GetValue(x, y)
 
 	if err != nil {
-		fmt.Println("error: %s", err.Error())
+		fmt.Println("error:", err.Error())
 		// Just assume out of bounds is dead
 		return false
 	}
@@ -57,12 +122,48 @@ func (hl hashLife) IsAlive(x, y int64) bool {
 	return val
 }
 
+// Calls the function with the coordinates of every alive cell on the board
+func (hl hashLife) ForEachAlive(f func(x, y int64)) {
+	qt.ForEachAlive(hl.Node, f)
+}
+
+// Calls the function with the coordinates of every alive cell in a chunk of the board
+func (hl hashLife) ForEachAliveIn(min_x, min_y, max_x, max_y int64, f func(x, y int64)) {
+	qt.ForEachAliveIn(hl.Node, min_x, min_y, max_x, max_y, f)
+}
+
+// Calls the function for every non-empty block of 2^level by 2^level cells that overlaps a chunk of the board
+func (hl hashLife) ForEachBlockIn(level uint, min_x, min_y, max_x, max_y int64, f func(x, y int64, population uint64)) {
+	qt.ForEachBlockIn(hl.Node, level, min_x, min_y, max_x, max_y, func(x, y int64, block qt.Node) {
+		f(x, y, block.Population())
+	})
+}
+
+// Returns the smallest rectangle containing all the alive cells
+func (hl hashLife) BoundingBox() (min_x, min_y, max_x, max_y int64, ok bool) {
+	return qt.BoundingBox(hl.Node)
+}
+
 // Returns a copy of the board stepped to the next state of the simulation
 var deadNode qt.Node = qt.EmptyTree(64)
 
 func (hl hashLife) Step() common.GolBoard {
-	next := NextGeneration(hl.Node)
+	return pad(NextGeneration(hl.Node))
+}
+
+// Returns a copy of the board stepped n generations forward. The board is advanced by the powers of 2 that add up to n,
+// each of which HashLife can do in one go, so this is much faster than calling Step n times.
+func (hl hashLife) StepN(n uint64) common.GolBoard {
+	for j := uint(0); n != 0; j, n = j+1, n>>1 {
+		if n&1 == 1 {
+			hl = pad(Advance(hl.Node, j))
+		}
+	}
+	return hl
+}
 
+// Returns a board holding a node one level down from the top, as returned by NextGeneration and Advance
+func pad(next qt.Node) hashLife {
 	// We have to pad the result with dead cells, since
 	// NextGeneration returns a node one level down
 	return hashLife{
@@ -0,0 +1,118 @@
+package library
+
+// The patterns built into the program. Cells are drawn with 'O' for alive and '.' for dead, with the top row first.
+var builtin = []Entry{
+	// Still lifes
+	{Name: "block", Category: StillLife, Period: 1, Description: "The most common still life",
+		Picture: "OO\nOO"},
+	{Name: "beehive", Category: StillLife, Period: 1, Description: "The second most common still life",
+		Picture: ".OO.\nO..O\n.OO."},
+	{Name: "loaf", Category: StillLife, Period: 1,
+		Picture: ".OO.\nO..O\n.O.O\n..O."},
+	{Name: "boat", Category: StillLife, Period: 1,
+		Picture: "OO.\nO.O\n.O."},
+	{Name: "ship", Category: StillLife, Period: 1,
+		Picture: "OO.\nO.O\n.OO"},
+	{Name: "tub", Category: StillLife, Period: 1,
+		Picture: ".O.\nO.O\n.O."},
+	{Name: "pond", Category: StillLife, Period: 1,
+		Picture: ".OO.\nO..O\nO..O\n.OO."},
+	{Name: "long boat", Category: StillLife, Period: 1,
+		Picture: ".O..\nO.O.\n.O.O\n..OO"},
+	{Name: "snake", Category: StillLife, Period: 1,
+		Picture: "OO.O\nO.OO"},
+	{Name: "eater 1", Category: StillLife, Period: 1, Description: "Also called the fishhook. Eats gliders that hit it the right way",
+		Picture: "OO..\nO.O.\n..O.\n..OO"},
+
+	// Oscillators
+	{Name: "blinker", Category: Oscillator, Period: 2, Description: "The smallest and most common oscillator",
+		Picture: "OOO"},
+	{Name: "toad", Category: Oscillator, Period: 2,
+		Picture: ".OOO\nOOO."},
+	{Name: "beacon", Category: Oscillator, Period: 2,
+		Picture: "OO..\nOO..\n..OO\n..OO"},
+	{Name: "clock", Category: Oscillator, Period: 2,
+		Picture: "..O.\nO.O.\n.O.O\n.O.."},
+	{Name: "pulsar", Category: Oscillator, Period: 3, Description: "The most common period 3 oscillator",
+		Picture: "..OOO...OOO..\n" +
+			".............\n" +
+			"O....O.O....O\n" +
+			"O....O.O....O\n" +
+			"O....O.O....O\n" +
+			"..OOO...OOO..\n" +
+			".............\n" +
+			"..OOO...OOO..\n" +
+			"O....O.O....O\n" +
+			"O....O.O....O\n" +
+			"O....O.O....O\n" +
+			".............\n" +
+			"..OOO...OOO.."},
+	{Name: "kok's galaxy", Category: Oscillator, Period: 8,
+		Picture: "OOOOOO.OO\n" +
+			"OOOOOO.OO\n" +
+			".......OO\n" +
+			"OO.....OO\n" +
+			"OO.....OO\n" +
+			"OO.....OO\n" +
+			"OO.......\n" +
+			"OO.OOOOOO\n" +
+			"OO.OOOOOO"},
+	{Name: "pentadecathlon", Category: Oscillator, Period: 15,
+		Picture: "..O....O..\nOO.OOOO.OO\n..O....O.."},
+
+	// Spaceships
+	{Name: "glider", Category: Spaceship, Period: 4, Speed: "c/4", Description: "The smallest and most common spaceship, moving diagonally",
+		Picture: ".O.\n..O\nOOO"},
+	{Name: "lightweight spaceship", Category: Spaceship, Period: 4, Speed: "c/2", Description: "LWSS. The smallest orthogonal spaceship",
+		Picture: ".O..O\nO....\nO...O\nOOOO."},
+	{Name: "middleweight spaceship", Category: Spaceship, Period: 4, Speed: "c/2", Description: "MWSS",
+		Picture: "...O..\n.O...O\nO.....\nO....O\nOOOOO."},
+	{Name: "heavyweight spaceship", Category: Spaceship, Period: 4, Speed: "c/2", Description: "HWSS",
+		Picture: "...OO..\n.O....O\nO......\nO.....O\nOOOOOO."},
+
+	// Guns
+	{Name: "gosper glider gun", Category: Gun, Period: 30, Description: "The first gun found, which shoots a glider every 30 generations",
+		Picture: "........................O...........\n" +
+			"......................O.O...........\n" +
+			"............OO......OO............OO\n" +
+			"...........O...O....OO............OO\n" +
+			"OO........O.....O...OO..............\n" +
+			"OO........O...O.OO....O.O...........\n" +
+			"..........O.....O.......O...........\n" +
+			"...........O...O....................\n" +
+			"............OO......................"},
+	{Name: "simkin glider gun", Category: Gun, Period: 120, Description: "The smallest gun by population when it was found",
+		Picture: "OO.....OO........................\n" +
+			"OO.....OO........................\n" +
+			".................................\n" +
+			"....OO...........................\n" +
+			"....OO...........................\n" +
+			".................................\n" +
+			".................................\n" +
+			".................................\n" +
+			".................................\n" +
+			"......................OO.OO......\n" +
+			".....................O.....O.....\n" +
+			".....................O......O..OO\n" +
+			".....................OOO...O...OO\n" +
+			"..........................O......\n" +
+			".................................\n" +
+			".................................\n" +
+			".................................\n" +
+			"....................OO...........\n" +
+			"....................O............\n" +
+			".....................OOO.........\n" +
+			".......................O........."},
+
+	// Methuselahs
+	{Name: "r-pentomino", Category: Methuselah, Lifespan: 1103, Description: "Stabilizes into 116 cells, including six gliders",
+		Picture: ".OO\nOO.\n.O."},
+	{Name: "diehard", Category: Methuselah, Lifespan: 130, Description: "Dies out completely",
+		Picture: "......O.\nOO......\n.O...OOO"},
+	{Name: "acorn", Category: Methuselah, Lifespan: 5206, Description: "Stabilizes into 633 cells, including 13 gliders",
+		Picture: ".O.....\n...O...\nOO..OOO"},
+	{Name: "b-heptomino", Category: Methuselah, Lifespan: 148,
+		Picture: "O.OO\nOOO.\n.O.."},
+	{Name: "pi-heptomino", Category: Methuselah, Lifespan: 173,
+		Picture: "OOO\nO.O\nO.O"},
+}
@@ -0,0 +1,210 @@
+/*
+library is a catalog of well known patterns, like still lifes, oscillators, spaceships, guns and methuselahs, built into
+the program, which can be extended with json files from a directory
+*/
+package library
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// The kinds of patterns in the library
+const (
+	StillLife  = "still life"
+	Oscillator = "oscillator"
+	Spaceship  = "spaceship"
+	Gun        = "gun"
+	Methuselah = "methuselah"
+)
+
+// A pattern in the library
+type Entry struct {
+	Name        string
+	Category    string
+	Description string
+	// The period of the pattern, or 0 if it doesn't have one
+	Period uint64
+	// How fast the pattern moves, like "c/4", or "" if it doesn't
+	Speed string
+	// The generation a methuselah stabilizes at
+	Lifespan uint64
+	// The cells, with 'O' for alive and '.' for dead, with the top row first. Only used for the patterns built in.
+	Picture string `json:"-"`
+	// The alive cells, with the lower left corner of the picture at (0,0)
+	Cells []common.Cell `json:"-"`
+	// Where the entry came from: "built in", or the file it was read from
+	Source string `json:"-"`
+}
+
+// A collection of patterns, by name
+type Library struct {
+	entries map[string]Entry
+}
+
+// Returns a library of the built in patterns
+func New() *Library {
+	lib := &Library{entries: map[string]Entry{}}
+	for _, entry := range builtin {
+		entry.Cells = parsePicture(entry.Picture)
+		entry.Source = "built in"
+		lib.entries[key(entry.Name)] = entry
+	}
+	return lib
+}
+
+// Names are looked up without caring about case
+func key(name string) string {
+	return strings.ToLower(name)
+}
+
+// Returns the cells in a picture, with the lower left corner of the picture at (0,0)
+func parsePicture(picture string) []common.Cell {
+	var cells []common.Cell
+	rows := strings.Split(picture, "\n")
+	for i, row := range rows {
+		for x, c := range row {
+			if c == 'O' {
+				cells = append(cells, common.Cell{X: int64(x), Y: int64(len(rows) - 1 - i)})
+			}
+		}
+	}
+	return cells
+}
+
+// A pattern file in a library directory: a json board, along with what the library knows about the pattern
+type jsonEntry struct {
+	Entry
+	AliveCells [][]int64
+}
+
+/*
+Adds every .json file in a directory to the library. The files are json boards, like the ones load reads, which can also
+have the fields of an entry, like Name, Category and Period. Patterns without a name are named after their file.
+Patterns with the same name as one already in the library replace its cells, and any fields the file has.
+*/
+func (lib *Library) AddDir(dir string) error {
+	filenames, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return err
+	}
+
+	for _, filename := range filenames {
+		file, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		var je jsonEntry
+		if err := json.Unmarshal(file, &je); err != nil {
+			return errors.New(filename + ": " + err.Error())
+		}
+
+		entry := je.Entry
+		if entry.Name == "" {
+			entry.Name = strings.TrimSuffix(filepath.Base(filename), ".json")
+		}
+		// Anything the file doesn't say about a pattern already in the library stays the same
+		if existing, ok := lib.entries[key(entry.Name)]; ok {
+			entry = merge(existing, entry)
+		}
+		if entry.Category == "" {
+			entry.Category = "other"
+		}
+		entry.Source = filename
+		entry.Cells = nil
+		for _, cell := range je.AliveCells {
+			if len(cell) != 2 {
+				return errors.New(filename + ": expected each alive cell to be an x and a y")
+			}
+			entry.Cells = append(entry.Cells, common.Cell{X: cell[0], Y: cell[1]})
+		}
+		lib.entries[key(entry.Name)] = entry
+	}
+	return nil
+}
+
+// Returns the entry with the fields that are set in the update replaced
+func merge(entry, update Entry) Entry {
+	entry.Name = update.Name
+	if update.Category != "" {
+		entry.Category = update.Category
+	}
+	if update.Description != "" {
+		entry.Description = update.Description
+	}
+	if update.Period != 0 {
+		entry.Period = update.Period
+	}
+	if update.Speed != "" {
+		entry.Speed = update.Speed
+	}
+	if update.Lifespan != 0 {
+		entry.Lifespan = update.Lifespan
+	}
+	return entry
+}
+
+// Returns the pattern with the given name
+func (lib *Library) Find(name string) (Entry, bool) {
+	entry, ok := lib.entries[key(name)]
+	return entry, ok
+}
+
+// Returns the patterns in a category, or all of them if the category is "", sorted by category and then name
+func (lib *Library) List(category string) []Entry {
+	return lib.filter(func(entry Entry) bool {
+		return category == "" || key(entry.Category) == key(category)
+	})
+}
+
+// Returns the patterns with the text in their name, category, description or speed, sorted by category and then name
+func (lib *Library) Search(text string) []Entry {
+	text = key(text)
+	return lib.filter(func(entry Entry) bool {
+		for _, field := range []string{entry.Name, entry.Category, entry.Description, entry.Speed} {
+			if strings.Contains(key(field), text) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Returns the categories of the patterns in the library, in order
+func (lib *Library) Categories() []string {
+	seen := map[string]bool{}
+	var categories []string
+	for _, entry := range lib.entries {
+		if !seen[entry.Category] {
+			seen[entry.Category] = true
+			categories = append(categories, entry.Category)
+		}
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+func (lib *Library) filter(keep func(Entry) bool) []Entry {
+	var entries []Entry
+	for _, entry := range lib.entries {
+		if keep(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Category != entries[j].Category {
+			return entries[i].Category < entries[j].Category
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
@@ -0,0 +1,101 @@
+package library_test
+
+import (
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/analysis"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/hashlife"
+	. "github.com/mitchellgordon95/ConwaysGOL/library"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+var _ = Describe("Library", func() {
+	var lib *Library
+	BeforeEach(func() {
+		lib = New()
+	})
+
+	board := func(entry Entry) common.GolBoard {
+		return hashlife.NewHashLifeBoard().AddCells(entry.Cells)
+	}
+
+	It("has the periods and speeds right", func() {
+		for _, category := range []string{StillLife, Oscillator, Spaceship} {
+			for _, entry := range lib.List(category) {
+				cycle, ok := analysis.FindCycle(board(entry), 100, true)
+				Expect(ok).To(BeTrue(), entry.Name)
+				Expect(cycle.Start).To(Equal(uint64(0)), entry.Name)
+				Expect(cycle.Period).To(Equal(entry.Period), entry.Name)
+
+				speed := ""
+				if distance := max(abs(cycle.DX), abs(cycle.DY)); distance > 0 {
+					speed = fmt.Sprintf("c/%d", int64(cycle.Period)/distance)
+				}
+				Expect(speed).To(Equal(entry.Speed), entry.Name)
+			}
+		}
+	})
+	It("has guns that shoot a glider every period", func() {
+		for _, entry := range lib.List(Gun) {
+			start := board(entry).StepN(10 * entry.Period).Population()
+			Expect(board(entry).StepN(11*entry.Period).Population()-start).To(Equal(uint64(5)), entry.Name)
+		}
+	})
+	It("has the lifespans of short lived methuselahs right", func() {
+		for _, entry := range lib.List(Methuselah) {
+			if entry.Lifespan > 200 {
+				continue
+			}
+			lifespan, ok := analysis.FindLifespan(board(entry), 1000)
+			Expect(ok).To(BeTrue(), entry.Name)
+			Expect(lifespan.Generation).To(Equal(entry.Lifespan), entry.Name)
+		}
+	})
+	It("finds patterns by name, category and description", func() {
+		glider, ok := lib.Find("Glider")
+		Expect(ok).To(BeTrue())
+		Expect(glider.Category).To(Equal(Spaceship))
+		Expect(lib.List(Gun)).To(HaveLen(2))
+		Expect(lib.Search("LWSS")[0].Name).To(Equal("lightweight spaceship"))
+		Expect(lib.Categories()).To(ContainElement(Methuselah))
+	})
+	It("reads patterns from a directory", func() {
+		dir, err := ioutil.TempDir("", "library")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		Expect(ioutil.WriteFile(filepath.Join(dir, "bit.json"), []byte(`{"AliveCells": [[0,0]], "Category": "junk"}`), 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(dir, "other.json"), []byte(`{"AliveCells": [[0,0],[1,1]], "Name": "Glider"}`), 0644)).To(Succeed())
+		Expect(lib.AddDir(dir)).To(Succeed())
+
+		bit, ok := lib.Find("bit")
+		Expect(ok).To(BeTrue())
+		Expect(bit.Category).To(Equal("junk"))
+		Expect(bit.Cells).To(Equal([]common.Cell{{X: 0, Y: 0}}))
+
+		// Patterns in the directory replace built in ones with the same name, but keep what the file doesn't say
+		glider, _ := lib.Find("glider")
+		Expect(glider.Cells).To(HaveLen(2))
+		Expect(glider.Category).To(Equal(Spaceship))
+
+		Expect(lib.AddDir(filepath.Join(dir, "missing"))).ToNot(Succeed())
+	})
+})
+
+func abs(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func max(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -1,12 +1,12 @@
 package main
 
 import (
-	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"fmt"
 	"github.com/mitchellgordon95/ConwaysGOL/display"
 	gm "github.com/mitchellgordon95/ConwaysGOL/game_manager"
-	"github.com/mitchellgordon95/ConwaysGOL/hashlife"
 	"gopkg.in/urfave/cli.v1"
 	"os"
+	"os/signal"
 )
 
 func main() {
@@ -21,34 +21,104 @@ func main() {
 		},
 		cli.BoolFlag{
 			Name:  "gui,g",
-			Usage: "show the game board in a gui window",
+			Usage: "show the game board in a web browser, served from a local http server",
+		},
+		cli.StringFlag{
+			Name:  "addr",
+			Value: "127.0.0.1:8080",
+			Usage: "the address to serve the gui on",
+		},
+		cli.BoolFlag{
+			Name:  "tui,t",
+			Usage: "run the text interface as an interactive terminal UI, where clicking and dragging on the board draws (left button) and erases (right button) cells. Requires an xterm compatible terminal",
+		},
+		cli.StringFlag{
+			Name:  "record",
+			Usage: "record the session to a file as an asciinema cast, which can be played back with \"asciinema play\"",
+		},
+		cli.StringFlag{
+			Name:  "library",
+			Usage: "a directory of json pattern files to add to the built in pattern library",
+		},
+		cli.StringFlag{
+			Name:  "script",
+			Usage: "a file of commands to run at startup, before reading any from the user",
 		},
 		cli.IntFlag{
 			Name:  "size,s",
 			Usage: "The size of the gameboard to show. Defaults to 16. Note that this is just the view, the actual size is 2^64",
 		},
 	}
+	app.Flags = append(app.Flags, soupFlags...)
+	app.Commands = commands
+
 	app.Action = func(c *cli.Context) error {
-		file := c.String("file")
-		var board common.GolBoard
-		if file != "" {
-			// TODO: read from file
-			board = nil
-		} else {
-			board = hashlife.NewHashLifeBoard()
+		board, err := loadBoard(c)
+		if err != nil {
+			return cli.NewExitError("Could not load the board: "+err.Error(), 1)
 		}
 
-		displayer := display.NewTextDisplayer(os.Stdout)
+		opts := gm.Options{LibraryDir: c.String("library"), Script: c.String("script")}
 
 		size := c.Int("size")
 		if size == 0 {
 			size = 16
 		}
 
-		gm.NewTextManager(board, os.Stdin, displayer, int64(size)).Manage()
+		if c.Bool("gui") {
+			displayer, err := display.NewWebDisplayer()
+			if err != nil {
+				return cli.NewExitError("Could not start the gui: "+err.Error(), 1)
+			}
+			manager, url, err := gm.NewGuiManager(board, displayer, int64(size), c.String("addr"), opts)
+			if err != nil {
+				return cli.NewExitError("Could not start the gui: "+err.Error(), 1)
+			}
+			fmt.Println("Serving the game at " + url)
+			manager.Manage()
+			return nil
+		}
+
+		displayer := display.NewRecordingDisplayer(os.Stdout)
+		if file := c.String("record"); file != "" {
+			if err := displayer.(display.Recorder).StartRecording(file); err != nil {
+				return cli.NewExitError("Could not start recording: "+err.Error(), 1)
+			}
+		}
+
+		if c.Bool("tui") {
+			manager, restore, err := gm.NewTUIManager(board, os.Stdin, os.Stdout, displayer, int64(size), opts)
+			if err != nil {
+				return cli.NewExitError("Could not start the terminal UI: "+err.Error(), 1)
+			}
+			defer restore()
+
+			// Make sure the terminal is usable again if the user hits Ctrl-C
+			exitOnInterrupt(displayer, restore)
+
+			manager.Manage()
+			return nil
+		}
+
+		exitOnInterrupt(displayer, func() {})
+		gm.NewTextManager(board, os.Stdin, displayer, int64(size), opts).Manage()
 
 		return nil
 	}
 
 	app.Run(os.Args)
 }
+
+// Finishes any recording when the user hits Ctrl-C, so the file isn't cut off halfway, then cleans up and exits
+func exitOnInterrupt(displayer display.Displayer, cleanup func()) {
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		if recorder, ok := displayer.(display.Recorder); ok && recorder.Recording() {
+			recorder.StopRecording()
+		}
+		cleanup()
+		os.Exit(1)
+	}()
+}
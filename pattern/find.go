@@ -0,0 +1,77 @@
+package pattern
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"sort"
+)
+
+// Where a pattern was found on a board
+type Match struct {
+	// The lower left corner of the bounding box of the pattern
+	X, Y int64
+	// How the pattern was turned around
+	Orientation Orientation
+}
+
+/*
+Returns every place on the board the pattern is, in any of the 8 orientations, sorted by y and then x. The cells in the
+pattern's bounding box have to match exactly, and if border is true, the cells just around it have to be dead too, so
+the pattern isn't part of something bigger. Only the alive cells on the board are looked at, so empty space costs nothing.
+*/
+func Find(board common.GolBoard, p Pattern, border bool) []Match {
+	if p.Population() == 0 {
+		return nil
+	}
+
+	// Symmetric patterns look the same in more than one orientation, and should only be found once
+	var oriented []Pattern
+	var orientations []Orientation
+	seen := map[string]bool{}
+	for _, o := range Orientations {
+		q := p.Orient(o)
+		if !seen[q.Key()] {
+			seen[q.Key()] = true
+			oriented = append(oriented, q)
+			orientations = append(orientations, o)
+		}
+	}
+
+	var matches []Match
+	board.ForEachAlive(func(x, y int64) {
+		for i, q := range oriented {
+			// The first cell of the pattern is its lowest, leftmost one, so if the pattern is here, this cell is it
+			anchor := q.Cells[0]
+			if at_x, at_y := x-anchor.X, y-anchor.Y; q.matches(board, at_x, at_y, border) {
+				matches = append(matches, Match{at_x, at_y, orientations[i]})
+			}
+		}
+	})
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Y != matches[j].Y {
+			return matches[i].Y < matches[j].Y
+		}
+		return matches[i].X < matches[j].X
+	})
+	return matches
+}
+
+// Returns whether the pattern is on the board with the lower left corner of its bounding box at (x, y)
+func (p Pattern) matches(board common.GolBoard, x, y int64, border bool) bool {
+	for _, cell := range p.Cells {
+		if !board.IsAlive(x+cell.X, y+cell.Y) {
+			return false
+		}
+	}
+
+	// All of the pattern's cells are there, so it's a match if nothing else is
+	min_x, min_y, max_x, max_y := x, y, x+p.Width, y+p.Height
+	if border {
+		min_x, min_y, max_x, max_y = min_x-1, min_y-1, max_x+1, max_y+1
+	}
+	count := 0
+	board.ForEachAliveIn(min_x, min_y, max_x, max_y, func(x, y int64) {
+		count++
+	})
+	return count == len(p.Cells)
+}
@@ -0,0 +1,45 @@
+package pattern_test
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/hashlife"
+	. "github.com/mitchellgordon95/ConwaysGOL/pattern"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Find", func() {
+	var glider, block Pattern
+	BeforeEach(func() {
+		glider, _, _ = FromCells([]common.Cell{{X: 1, Y: 2}, {X: 2, Y: 1}, {X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}})
+		block, _, _ = FromCells([]common.Cell{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}})
+	})
+
+	It("finds the pattern in every orientation", func() {
+		board := hashlife.NewHashLifeBoard()
+		board = glider.Place(board, 0, 0)
+		board = glider.Orient(Orientation{Turns: 1}).Place(board, 100, -50)
+		board = glider.Orient(Orientation{Turns: 2, Flip: true}).Place(board, -1000, 1000)
+
+		matches := Find(board, glider, true)
+		Expect(matches).To(HaveLen(3))
+		Expect(matches[0]).To(Equal(Match{X: 100, Y: -50, Orientation: Orientation{Turns: 1}}))
+		Expect(matches[1].X).To(Equal(int64(0)))
+		Expect(matches[2].X).To(Equal(int64(-1000)))
+	})
+	It("only finds symmetric patterns once", func() {
+		board := block.Place(hashlife.NewHashLifeBoard(), 5, 5)
+		Expect(Find(board, block, false)).To(HaveLen(1))
+	})
+	It("doesn't find the pattern with extra cells inside it", func() {
+		board := block.Place(hashlife.NewHashLifeBoard(), 0, 0)
+		Expect(Find(board, glider, false)).To(BeEmpty())
+		board = glider.Place(hashlife.NewHashLifeBoard(), 0, 0).AddCell(0, 2)
+		Expect(Find(board, glider, false)).To(BeEmpty())
+	})
+	It("can require a dead border", func() {
+		board := block.Place(hashlife.NewHashLifeBoard(), 0, 0).AddCell(2, 0)
+		Expect(Find(board, block, false)).To(HaveLen(1))
+		Expect(Find(board, block, true)).To(BeEmpty())
+	})
+})
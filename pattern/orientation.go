@@ -0,0 +1,61 @@
+package pattern
+
+import (
+	"fmt"
+)
+
+// One of the 8 ways to turn a pattern around: mirror it left to right or not, and then rotate it
+type Orientation struct {
+	// Number of quarter turns counterclockwise, from 0 to 3
+	Turns int
+	// Whether to mirror the pattern left to right before turning it
+	Flip bool
+}
+
+// All 8 orientations, starting with the pattern as it is
+var Orientations = []Orientation{
+	{0, false}, {1, false}, {2, false}, {3, false},
+	{0, true}, {1, true}, {2, true}, {3, true},
+}
+
+// Returns where the orientation takes a cell
+func (o Orientation) Apply(x, y int64) (int64, int64) {
+	if o.Flip {
+		x = -x
+	}
+	switch o.Turns & 3 {
+	case 1:
+		return -y, x
+	case 2:
+		return -x, -y
+	case 3:
+		return y, -x
+	}
+	return x, y
+}
+
+// Returns the orientation that has the same effect as doing this one and then the other
+func (o Orientation) Then(other Orientation) Orientation {
+	// Mirroring after turning is the same as mirroring first and turning the other way
+	turns := o.Turns
+	if other.Flip {
+		turns = -turns
+	}
+	return Orientation{(turns + other.Turns) & 3, o.Flip != other.Flip}
+}
+
+// Returns the orientation that undoes this one
+func (o Orientation) Inverse() Orientation {
+	if o.Flip {
+		// A flip followed by a turn is its own inverse
+		return o
+	}
+	return Orientation{(4 - o.Turns) & 3, false}
+}
+
+func (o Orientation) String() string {
+	if o.Flip {
+		return fmt.Sprintf("flipped and turned %d degrees", 90*o.Turns)
+	}
+	return fmt.Sprintf("turned %d degrees", 90*o.Turns)
+}
@@ -0,0 +1,165 @@
+/*
+pattern describes groups of alive cells independently of where they are on the board, so they can be compared, hashed,
+turned around and placed elsewhere
+*/
+package pattern
+
+import (
+	"encoding/binary"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"hash/fnv"
+	"sort"
+)
+
+/*
+A Pattern is a group of alive cells moved so the lower left corner of their bounding box is at (0,0).
+The cells are kept in order, so two patterns with the same shape have exactly the same cells, wherever they came from.
+*/
+type Pattern struct {
+	// The alive cells, sorted by y and then by x
+	Cells []common.Cell
+	// The size of the bounding box
+	Width, Height int64
+}
+
+/*
+Returns the pattern formed by the cells, and where the lower left corner of its bounding box was.
+The slice is reused for the pattern, so it shouldn't be used afterwards.
+*/
+func FromCells(cells []common.Cell) (p Pattern, x, y int64) {
+	if len(cells) == 0 {
+		return Pattern{}, 0, 0
+	}
+
+	min_x, min_y, max_x, max_y := cells[0].X, cells[0].Y, cells[0].X, cells[0].Y
+	for _, cell := range cells {
+		if cell.X < min_x {
+			min_x = cell.X
+		}
+		if cell.X > max_x {
+			max_x = cell.X
+		}
+		if cell.Y < min_y {
+			min_y = cell.Y
+		}
+		if cell.Y > max_y {
+			max_y = cell.Y
+		}
+	}
+
+	for i := range cells {
+		cells[i].X -= min_x
+		cells[i].Y -= min_y
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i].Y != cells[j].Y {
+			return cells[i].Y < cells[j].Y
+		}
+		return cells[i].X < cells[j].X
+	})
+
+	return Pattern{cells, max_x - min_x + 1, max_y - min_y + 1}, min_x, min_y
+}
+
+// Returns the pattern formed by every alive cell on the board, and where the lower left corner of its bounding box is
+func FromBoard(board common.GolBoard) (p Pattern, x, y int64) {
+	var cells []common.Cell
+	board.ForEachAlive(func(x, y int64) {
+		cells = append(cells, common.Cell{X: x, Y: y})
+	})
+	return FromCells(cells)
+}
+
+// Returns the number of alive cells in the pattern
+func (p Pattern) Population() int {
+	return len(p.Cells)
+}
+
+// Returns the pattern turned around into the given orientation
+func (p Pattern) Orient(o Orientation) Pattern {
+	cells := make([]common.Cell, len(p.Cells))
+	for i, cell := range p.Cells {
+		cells[i].X, cells[i].Y = o.Apply(cell.X, cell.Y)
+	}
+	oriented, _, _ := FromCells(cells)
+	return oriented
+}
+
+/*
+Returns the pattern in its canonical orientation, and the orientation that turns the pattern into it.
+The canonical orientation is the same for a pattern however it's turned around, so patterns that are the same up to
+rotation and reflection have the same canonical pattern.
+*/
+func (p Pattern) Canonical() (Pattern, Orientation) {
+	best, bestOrientation := p, Orientations[0]
+	for _, o := range Orientations[1:] {
+		if oriented := p.Orient(o); oriented.less(best) {
+			best, bestOrientation = oriented, o
+		}
+	}
+	return best, bestOrientation
+}
+
+// Returns whether p comes before other in an arbitrary but fixed order of patterns
+func (p Pattern) less(other Pattern) bool {
+	if p.Width != other.Width {
+		return p.Width < other.Width
+	}
+	if p.Height != other.Height {
+		return p.Height < other.Height
+	}
+	if len(p.Cells) != len(other.Cells) {
+		return len(p.Cells) < len(other.Cells)
+	}
+	for i, cell := range p.Cells {
+		if cell != other.Cells[i] {
+			if cell.Y != other.Cells[i].Y {
+				return cell.Y < other.Cells[i].Y
+			}
+			return cell.X < other.Cells[i].X
+		}
+	}
+	return false
+}
+
+// Returns whether two patterns have the same cells
+func (p Pattern) Equal(other Pattern) bool {
+	return p.Key() == other.Key()
+}
+
+/*
+Returns a string that's the same for two patterns exactly when they have the same cells, for use as a map key.
+It isn't meant to be read.
+*/
+func (p Pattern) Key() string {
+	buf := make([]byte, 0, 16*(len(p.Cells)+1))
+	buf = appendInt(buf, p.Width)
+	buf = appendInt(buf, p.Height)
+	for _, cell := range p.Cells {
+		buf = appendInt(buf, cell.X)
+		buf = appendInt(buf, cell.Y)
+	}
+	return string(buf)
+}
+
+// Returns a hash of the pattern, which is the same every time the program runs, so it can be saved and compared later
+func (p Pattern) Hash() uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(p.Key()))
+	return h.Sum64()
+}
+
+// Returns a copy of the board with the pattern on it, with the lower left corner of its bounding box at (x, y)
+func (p Pattern) Place(board common.GolBoard, x, y int64) common.GolBoard {
+	cells := make([]common.Cell, len(p.Cells))
+	for i, cell := range p.Cells {
+		cells[i] = common.Cell{X: cell.X + x, Y: cell.Y + y}
+	}
+	return board.AddCells(cells)
+}
+
+func appendInt(buf []byte, val int64) []byte {
+	var bytes [8]byte
+	binary.LittleEndian.PutUint64(bytes[:], uint64(val))
+	return append(buf, bytes[:]...)
+}
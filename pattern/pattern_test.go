@@ -0,0 +1,150 @@
+package pattern_test
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/hashlife"
+	. "github.com/mitchellgordon95/ConwaysGOL/pattern"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pattern", func() {
+	var glider []common.Cell
+	BeforeEach(func() {
+		glider = []common.Cell{{X: 0, Y: 0}, {X: 1, Y: -1}, {X: 2, Y: -1}, {X: 2, Y: 0}, {X: 2, Y: 1}}
+	})
+
+	// Returns the cells moved by (dx, dy)
+	moved := func(cells []common.Cell, dx, dy int64) []common.Cell {
+		out := make([]common.Cell, len(cells))
+		for i, cell := range cells {
+			out[i] = common.Cell{X: cell.X + dx, Y: cell.Y + dy}
+		}
+		return out
+	}
+
+	It("moves the pattern to the origin", func() {
+		p, x, y := FromCells(moved(glider, 5, 7))
+		Expect(x).To(Equal(int64(5)))
+		Expect(y).To(Equal(int64(6)))
+		Expect(p.Width).To(Equal(int64(3)))
+		Expect(p.Height).To(Equal(int64(3)))
+		Expect(p.Cells).To(Equal([]common.Cell{{X: 1, Y: 0}, {X: 2, Y: 0}, {X: 0, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}}))
+	})
+	It("is the same wherever the cells were", func() {
+		a, _, _ := FromCells(moved(glider, 0, 0))
+		b, _, _ := FromCells(moved(glider, -1000, 1<<40))
+		Expect(a.Equal(b)).To(BeTrue())
+		Expect(a.Key()).To(Equal(b.Key()))
+		Expect(a.Hash()).To(Equal(b.Hash()))
+	})
+	It("tells different patterns apart", func() {
+		a, _, _ := FromCells(glider)
+		b, _, _ := FromCells([]common.Cell{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 2}})
+		Expect(a.Equal(b)).To(BeFalse())
+		Expect(a.Hash()).NotTo(Equal(b.Hash()))
+	})
+	It("reads the cells off a board", func() {
+		board := hashlife.NewHashLifeBoard().AddCells(moved(glider, 3, 3))
+		fromBoard, x, y := FromBoard(board)
+		fromCells, _, _ := FromCells(glider)
+		Expect(fromBoard.Equal(fromCells)).To(BeTrue())
+		Expect([]int64{x, y}).To(Equal([]int64{3, 2}))
+	})
+	It("places the pattern back on a board", func() {
+		p, _, _ := FromCells(glider)
+		board := p.Place(hashlife.NewHashLifeBoard(), 10, 20)
+		placed, x, y := FromBoard(board)
+		Expect(placed.Equal(p)).To(BeTrue())
+		Expect([]int64{x, y}).To(Equal([]int64{10, 20}))
+	})
+
+	Describe("Canonical", func() {
+		It("is the same in every orientation", func() {
+			p, _, _ := FromCells(glider)
+			canonical, _ := p.Canonical()
+			for _, o := range Orientations {
+				oriented, _ := p.Orient(o).Canonical()
+				Expect(oriented.Equal(canonical)).To(BeTrue())
+			}
+		})
+		It("returns the orientation that gives the canonical pattern", func() {
+			p, _, _ := FromCells(glider)
+			canonical, o := p.Canonical()
+			Expect(p.Orient(o).Equal(canonical)).To(BeTrue())
+		})
+		It("matches mirror images", func() {
+			// The R-pentomino and its mirror image are the same up to reflection
+			r, _, _ := FromCells([]common.Cell{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: -1}, {X: 2, Y: 1}})
+			mirrored := r.Orient(Orientation{Flip: true})
+			Expect(mirrored.Equal(r)).To(BeFalse())
+			a, _ := r.Canonical()
+			b, _ := mirrored.Canonical()
+			Expect(a.Equal(b)).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("Orientation", func() {
+	It("turns counterclockwise", func() {
+		x, y := Orientation{Turns: 1}.Apply(1, 0)
+		Expect([]int64{x, y}).To(Equal([]int64{0, 1}))
+	})
+	It("combines orientations", func() {
+		for _, a := range Orientations {
+			for _, b := range Orientations {
+				x, y := a.Apply(2, 5)
+				x, y = b.Apply(x, y)
+				cx, cy := a.Then(b).Apply(2, 5)
+				Expect([]int64{cx, cy}).To(Equal([]int64{x, y}))
+			}
+		}
+	})
+	It("undoes orientations", func() {
+		for _, o := range Orientations {
+			x, y := o.Apply(2, 5)
+			x, y = o.Inverse().Apply(x, y)
+			Expect([]int64{x, y}).To(Equal([]int64{2, 5}))
+		}
+	})
+})
+
+var _ = Describe("Region", func() {
+	var board common.GolBoard
+	BeforeEach(func() {
+		// An L with a gap below it, in the region from (10,20) to (13,24)
+		board = hashlife.NewHashLifeBoard().AddCells([]common.Cell{{X: 10, Y: 23}, {X: 10, Y: 22}, {X: 11, Y: 22}})
+	})
+
+	It("keeps the empty space in the region", func() {
+		region := FromRegion(board, 10, 20, 13, 24)
+		Expect(region.Width).To(Equal(int64(3)))
+		Expect(region.Height).To(Equal(int64(4)))
+		Expect(region.Cells).To(ConsistOf(common.Cell{X: 0, Y: 3}, common.Cell{X: 0, Y: 2}, common.Cell{X: 1, Y: 2}))
+	})
+	It("turns the region around", func() {
+		region := FromRegion(board, 10, 20, 13, 24).Orient(Orientation{Turns: 1})
+		Expect(region.Width).To(Equal(int64(4)))
+		Expect(region.Height).To(Equal(int64(3)))
+		Expect(region.Cells).To(ConsistOf(common.Cell{X: 0, Y: 0}, common.Cell{X: 1, Y: 0}, common.Cell{X: 1, Y: 1}))
+
+		flipped := FromRegion(board, 10, 20, 13, 24).Orient(Orientation{Flip: true})
+		Expect(flipped.Cells).To(ConsistOf(common.Cell{X: 2, Y: 3}, common.Cell{X: 2, Y: 2}, common.Cell{X: 1, Y: 2}))
+	})
+	It("pastes in each mode", func() {
+		region := FromRegion(board, 10, 20, 13, 24)
+		target := hashlife.NewHashLifeBoard().AddCells([]common.Cell{{X: 0, Y: 3}, {X: 2, Y: 0}, {X: 5, Y: 5}})
+
+		or := region.Paste(target, 0, 0, PasteOr)
+		Expect(or.Population()).To(Equal(uint64(5)))
+
+		xor := region.Paste(target, 0, 0, PasteXor)
+		Expect(xor.Population()).To(Equal(uint64(4)))
+		Expect(xor.IsAlive(0, 3)).To(BeFalse())
+
+		cp := region.Paste(target, 0, 0, PasteCopy)
+		Expect(cp.Population()).To(Equal(uint64(4)))
+		Expect(cp.IsAlive(2, 0)).To(BeFalse())
+		Expect(cp.IsAlive(5, 5)).To(BeTrue())
+	})
+})
@@ -0,0 +1,110 @@
+package pattern
+
+import (
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"strings"
+)
+
+/*
+A Region is a rectangular piece of a board, such as a selection copied to the clipboard. Unlike a pattern, the empty
+space in the rectangle is part of the region, so pasting it over other cells can clear them.
+*/
+type Region struct {
+	// The alive cells, relative to the lower left corner of the region
+	Cells []common.Cell
+	// The size of the region
+	Width, Height int64
+}
+
+// How a region is combined with the cells already on the board where it's pasted
+type PasteMode int
+
+const (
+	// Alive cells are added to the cells already there
+	PasteOr PasteMode = iota
+	// Alive cells flip the cells already there, so alive cells pasted onto alive cells die
+	PasteXor
+	// The region replaces whatever was there
+	PasteCopy
+)
+
+// Parses the name of a paste mode: or, xor or copy
+func ParsePasteMode(name string) (PasteMode, error) {
+	switch strings.ToLower(name) {
+	case "or":
+		return PasteOr, nil
+	case "xor":
+		return PasteXor, nil
+	case "copy":
+		return PasteCopy, nil
+	}
+	return PasteOr, fmt.Errorf("Unknown paste mode %q", name)
+}
+
+// Returns the region of the board from min coordinates (inclusive) to max coordinates (exclusive)
+func FromRegion(board common.GolBoard, min_x, min_y, max_x, max_y int64) Region {
+	region := Region{Width: max_x - min_x, Height: max_y - min_y}
+	board.ForEachAliveIn(min_x, min_y, max_x, max_y, func(x, y int64) {
+		region.Cells = append(region.Cells, common.Cell{X: x - min_x, Y: y - min_y})
+	})
+	return region
+}
+
+// Returns the region turned around into the given orientation, with its lower left corner still at (0,0)
+func (r Region) Orient(o Orientation) Region {
+	// Find where the corners of the region end up, to move it back to (0,0)
+	x1, y1 := o.Apply(0, 0)
+	x2, y2 := o.Apply(r.Width-1, r.Height-1)
+	dx, dy := -min(x1, x2), -min(y1, y2)
+
+	oriented := Region{Cells: make([]common.Cell, len(r.Cells)), Width: r.Width, Height: r.Height}
+	if o.Turns%2 == 1 {
+		oriented.Width, oriented.Height = r.Height, r.Width
+	}
+	for i, cell := range r.Cells {
+		x, y := o.Apply(cell.X, cell.Y)
+		oriented.Cells[i] = common.Cell{X: x + dx, Y: y + dy}
+	}
+	return oriented
+}
+
+func min(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Returns a copy of the board with the region pasted on it, with its lower left corner at (x, y)
+func (r Region) Paste(board common.GolBoard, x, y int64, mode PasteMode) common.GolBoard {
+	cells := make([]common.Cell, len(r.Cells))
+	for i, cell := range r.Cells {
+		cells[i] = common.Cell{X: cell.X + x, Y: cell.Y + y}
+	}
+
+	switch mode {
+	case PasteXor:
+		var alive, dead []common.Cell
+		for _, cell := range cells {
+			if board.IsAlive(cell.X, cell.Y) {
+				alive = append(alive, cell)
+			} else {
+				dead = append(dead, cell)
+			}
+		}
+		return board.KillCells(alive).AddCells(dead)
+	case PasteCopy:
+		board = Clear(board, x, y, x+r.Width, y+r.Height)
+	}
+	return board.AddCells(cells)
+}
+
+// Returns a copy of the board with every cell from min coordinates (inclusive) to max coordinates (exclusive) dead
+func Clear(board common.GolBoard, min_x, min_y, max_x, max_y int64) common.GolBoard {
+	var cells []common.Cell
+	board.ForEachAliveIn(min_x, min_y, max_x, max_y, func(x, y int64) {
+		cells = append(cells, common.Cell{X: x, Y: y})
+	})
+	return board.KillCells(cells)
+}
@@ -0,0 +1,131 @@
+package quadtree
+
+import (
+	"errors"
+	"sync"
+)
+
+// A boolean operation on the cells of two nodes
+type operation int
+
+const (
+	union operation = iota
+	intersect
+	xor
+	subtract
+)
+
+// The operation and the two nodes it was done on
+type operationKey struct {
+	op   operation
+	a, b Node
+}
+
+/*
+operationCache stores the results of boolean operations on nodes. Since nodes are canonical, the same pieces come up
+over and over again in big trees, so most of the work is only ever done once. It's split into shards like nodeCache.
+*/
+var operationCache [cacheShards]struct {
+	sync.Mutex
+	results map[operationKey]Node
+}
+
+func init() {
+	clearOperationCache()
+}
+
+// Called with the node cache being emptied, since results made of nodes no longer in it aren't canonical
+func clearOperationCache() {
+	for i := range operationCache {
+		operationCache[i].Lock()
+		operationCache[i].results = map[operationKey]Node{}
+		operationCache[i].Unlock()
+	}
+}
+
+// Returns a node with the cells that are alive in either node
+func Union(a, b Node) (Node, error) {
+	return combine(union, a, b)
+}
+
+// Returns a node with the cells that are alive in both nodes
+func Intersect(a, b Node) (Node, error) {
+	return combine(intersect, a, b)
+}
+
+// Returns a node with the cells that are alive in exactly one of the nodes
+func Xor(a, b Node) (Node, error) {
+	return combine(xor, a, b)
+}
+
+// Returns a node with the cells that are alive in a but not in b
+func Subtract(a, b Node) (Node, error) {
+	return combine(subtract, a, b)
+}
+
+func combine(op operation, a, b Node) (Node, error) {
+	if a.Level() != b.Level() {
+		return nil, errors.New("quadtree: can't combine nodes of different levels")
+	}
+	return apply(op, a, b), nil
+}
+
+// Does the operation on two nodes of the same level
+func apply(op operation, a, b Node) Node {
+	// Most of the time one of the nodes is empty, or they're the same, and the answer is easy
+	switch op {
+	case union:
+		if a == b || b.Population() == 0 {
+			return a
+		}
+		if a.Population() == 0 {
+			return b
+		}
+	case intersect:
+		if a == b || a.Population() == 0 {
+			return a
+		}
+		if b.Population() == 0 {
+			return b
+		}
+	case xor:
+		if a == b {
+			return EmptyTree(int(a.Level()) + 1)
+		}
+		if b.Population() == 0 {
+			return a
+		}
+		if a.Population() == 0 {
+			return b
+		}
+	case subtract:
+		if a == b {
+			return EmptyTree(int(a.Level()) + 1)
+		}
+		if a.Population() == 0 || b.Population() == 0 {
+			return a
+		}
+	}
+
+	// If neither node is empty, and they're different, they can't be leaves
+	key := operationKey{op, a, b}
+	shard := &operationCache[Shard(a.Hash()^b.Hash(), cacheShards)]
+	shard.Lock()
+	cached, ok := shard.results[key]
+	shard.Unlock()
+	if ok {
+		return cached
+	}
+
+	out := QuadNode(
+		apply(op, a.NW(), b.NW()),
+		apply(op, a.NE(), b.NE()),
+		apply(op, a.SW(), b.SW()),
+		apply(op, a.SE(), b.SE()),
+	)
+
+	shard.Lock()
+	shard.results[key] = out
+	shard.Unlock()
+	return out
+}
@@ -0,0 +1,55 @@
+package quadtree
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Boolean operations", func() {
+	var a, b Node
+	aCells := []common.Cell{{X: 0, Y: 0}, {X: -1, Y: 0}, {X: -150, Y: -10}, {X: 50, Y: -15}}
+	bCells := []common.Cell{{X: 0, Y: 0}, {X: 3, Y: 3}, {X: 50, Y: -15}, {X: -7, Y: 100}}
+
+	BeforeEach(func() {
+		// SetValues reorders the cells, so give it copies
+		a, _ = SetValues(EmptyTree(10), append([]common.Cell{}, aCells...), true)
+		b, _ = SetValues(EmptyTree(10), append([]common.Cell{}, bCells...), true)
+	})
+
+	// Returns the tree with exactly the given cells alive
+	tree := func(cells ...common.Cell) Node {
+		node, err := SetValues(EmptyTree(10), cells, true)
+		Expect(err).ToNot(HaveOccurred())
+		return node
+	}
+
+	It("finds the union", func() {
+		union, err := Union(a, b)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(union).To(BeIdenticalTo(tree(append(append([]common.Cell{}, aCells...), bCells...)...)))
+	})
+	It("finds the intersection", func() {
+		intersection, err := Intersect(a, b)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(intersection).To(BeIdenticalTo(tree(common.Cell{X: 0, Y: 0}, common.Cell{X: 50, Y: -15})))
+	})
+	It("finds the cells in exactly one", func() {
+		xor, err := Xor(a, b)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(xor).To(BeIdenticalTo(tree(common.Cell{X: -1, Y: 0}, common.Cell{X: -150, Y: -10}, common.Cell{X: 3, Y: 3}, common.Cell{X: -7, Y: 100})))
+		xor, _ = Xor(a, a)
+		Expect(xor).To(BeIdenticalTo(EmptyTree(10)))
+	})
+	It("subtracts", func() {
+		difference, err := Subtract(a, b)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(difference).To(BeIdenticalTo(tree(common.Cell{X: -1, Y: 0}, common.Cell{X: -150, Y: -10})))
+		difference, _ = Subtract(b, a)
+		Expect(difference).To(BeIdenticalTo(tree(common.Cell{X: 3, Y: 3}, common.Cell{X: -7, Y: 100})))
+	})
+	It("returns an error for nodes of different levels", func() {
+		_, err := Union(a, EmptyTree(5))
+		Expect(err).To(HaveOccurred())
+	})
+})
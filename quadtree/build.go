@@ -0,0 +1,77 @@
+package quadtree
+
+import (
+	"errors"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+)
+
+/*
+Returns a copy of the node with all the given cells set to the value. As with SetValue, (0,0) is the center of the node.
+Each subnode is only rebuilt once no matter how many of the cells are in it, so this is much faster than calling SetValue
+for every cell. The cells may be reordered. Returns an error if any of the cells is out of bounds.
+*/
+func SetValues(node Node, cells []common.Cell, val bool) (Node, error) {
+	// As with SetValue, work on the addressable node in the middle and then put it back
+	if node.Level() > 64 {
+		empty := node.NW().NW()
+		res, err := SetValues(QuadNode(node.NW().SE(), node.NE().SW(), node.SW().NE(), node.SE().NW()), cells, val)
+		if err != nil {
+			return nil, err
+		}
+		return QuadNode(
+			QuadNode(empty, empty, empty, res.NW()),
+			QuadNode(empty, empty, res.NE(), empty),
+			QuadNode(empty, res.SW(), empty, empty),
+			QuadNode(res.SE(), empty, empty, empty),
+		), nil
+	}
+
+	if node.Level() < 64 {
+		var half int64
+		if node.Level() > 0 {
+			half = int64(1) << (node.Level() - 1)
+		}
+		for _, cell := range cells {
+			if node.Level() == 0 && (cell.X != 0 || cell.Y != 0) || node.Level() > 0 && outOfBound(cell.X, cell.Y, half) {
+				return nil, errors.New("quadtree: grid location out of bound")
+			}
+		}
+	}
+
+	return setValues(node, 0, 0, cells, val), nil
+}
+
+// Sets the cells, which are all in a node centered at (x, y), to the value
+func setValues(node Node, x, y int64, cells []common.Cell, val bool) Node {
+	if len(cells) == 0 {
+		return node
+	}
+	if node.Level() == 0 {
+		return LeafNode(val)
+	}
+
+	// Split the cells up by the quadrant they're in
+	west, east := partition(cells, func(cell common.Cell) bool { return cell.X < x })
+	sw, nw := partition(west, func(cell common.Cell) bool { return cell.Y < y })
+	se, ne := partition(east, func(cell common.Cell) bool { return cell.Y < y })
+
+	posOffset, negOffset := childOffsets(node.Level())
+	return QuadNode(
+		setValues(node.NW(), x-posOffset, y-negOffset, nw, val),
+		setValues(node.NE(), x-negOffset, y-negOffset, ne, val),
+		setValues(node.SW(), x-posOffset, y-posOffset, sw, val),
+		setValues(node.SE(), x-negOffset, y-posOffset, se, val),
+	)
+}
+
+// Reorders the cells so the ones where first is true come first, and returns the two groups
+func partition(cells []common.Cell, first func(common.Cell) bool) ([]common.Cell, []common.Cell) {
+	i := 0
+	for j := range cells {
+		if first(cells[j]) {
+			cells[i], cells[j] = cells[j], cells[i]
+			i++
+		}
+	}
+	return cells[:i], cells[i:]
+}
@@ -0,0 +1,45 @@
+package quadtree
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SetValues", func() {
+	var cells []common.Cell
+	BeforeEach(func() {
+		cells = []common.Cell{
+			{X: 0, Y: 0}, {X: -1, Y: 0}, {X: -150, Y: -10}, {X: 50, Y: -15}, {X: -3, Y: 7}, {X: -1, Y: -1},
+		}
+	})
+
+	setOneByOne := func(tree Node, val bool) Node {
+		for _, cell := range cells {
+			tree, _ = tree.SetValue(cell.X, cell.Y, val)
+		}
+		return tree
+	}
+
+	It("gives the same node as setting the cells one at a time", func() {
+		tree, err := SetValues(EmptyTree(10), cells, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tree).To(BeIdenticalTo(setOneByOne(EmptyTree(10), true)))
+	})
+	It("works on a full size board, including the edges", func() {
+		cells = append(cells, common.Cell{X: -1 << 63, Y: 1<<63 - 1}, common.Cell{X: 1<<63 - 1, Y: -1 << 63})
+		tree, err := SetValues(EmptyTree(66), cells, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tree).To(BeIdenticalTo(setOneByOne(EmptyTree(66), true)))
+	})
+	It("kills cells", func() {
+		full := setOneByOne(EmptyTree(10), true)
+		tree, err := SetValues(full, cells[:3], false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tree.Population()).To(Equal(uint64(len(cells) - 3)))
+	})
+	It("returns an error for cells out of bounds", func() {
+		_, err := SetValues(EmptyTree(4), []common.Cell{{X: 0, Y: 0}, {X: 8, Y: 0}}, true)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -15,6 +15,20 @@ func (ln leafNode) Level() uint {
 	return 0
 }
 
+func (ln leafNode) Population() uint64 {
+	if ln {
+		return 1
+	}
+	return 0
+}
+
+func (ln leafNode) Hash() uint64 {
+	if ln {
+		return 0x9e3779b97f4a7c15
+	}
+	return 0x2545f4914f6cdd1d
+}
+
 func (ln leafNode) SetValue(x, y int64, value bool) (Node, error) {
 	if x != 0 || y != 0 {
 		return nil, errors.New("leafNode: grid location out of bound")
@@ -3,15 +3,28 @@ package quadtree
 import (
 	"errors"
 	"fmt"
+	"sync"
 )
 
 func init() {
 	// Initialize the node cache to be empty
-	nodeCache = map[quadNode]*quadNode{}
+	for i := range nodeCache {
+		nodeCache[i].nodes = map[quadNode]*quadNode{}
+	}
 }
 
-// nodeCache stores canonical copies of all quadnodes to reduce redundant memory consumption
-var nodeCache map[quadNode]*quadNode
+// The number of pieces the node cache is split into
+const cacheShards = 64
+
+/*
+nodeCache stores canonical copies of all quadnodes to reduce redundant memory consumption.
+It's split into shards by the hash of the node, each with its own lock, so trees can be built from more than one
+goroutine without them all waiting on each other.
+*/
+var nodeCache [cacheShards]struct {
+	sync.Mutex
+	nodes map[quadNode]*quadNode
+}
 
 // A quadNode points to the four sub-sections of the game board that it contains
 type quadNode struct {
@@ -19,31 +32,130 @@ type quadNode struct {
 	nw, ne, sw, se Node
 	// Level of the node in the tree. Determines the size of the board the node is responsible for.
 	level uint
+	// Number of alive cells in the node
+	population uint64
+	// Hash of the cells in the node
+	hash uint64
 }
 
-// TODO: Write garbage collection for cache
 func PrintCache() {
-	fmt.Printf("%v", nodeCache)
+	for i := range nodeCache {
+		nodeCache[i].Lock()
+		fmt.Printf("%v", nodeCache[i].nodes)
+		nodeCache[i].Unlock()
+	}
 	fmt.Println()
 }
 
+// Returns the number of nodes in the cache
+func CacheSize() int {
+	size := 0
+	for i := range nodeCache {
+		nodeCache[i].Lock()
+		size += len(nodeCache[i].nodes)
+		nodeCache[i].Unlock()
+	}
+	return size
+}
+
+// Empties the cache to free up memory. Existing nodes keep working, but new nodes won't be the same as equal nodes made
+// before, so they can't be compared with them.
+func ClearCache() {
+	for i := range nodeCache {
+		nodeCache[i].Lock()
+		nodeCache[i].nodes = map[quadNode]*quadNode{}
+		nodeCache[i].Unlock()
+	}
+	clearOperationCache()
+}
+
+/*
+Frees up memory by emptying the cache of every node that isn't part of one of the given trees. Unlike ClearCache, the nodes
+that are kept are still canonical, so new nodes can still be compared with them. Any other nodes still in use can't be.
+*/
+func CollectGarbage(roots ...Node) {
+	for i := range nodeCache {
+		nodeCache[i].Lock()
+		nodeCache[i].nodes = map[quadNode]*quadNode{}
+	}
+	for _, root := range roots {
+		keep(root)
+	}
+	for i := range nodeCache {
+		nodeCache[i].Unlock()
+	}
+	clearOperationCache()
+}
+
+// Puts a node and everything below it back into the cache. The caller holds the locks on every shard.
+func keep(node Node) {
+	qn, ok := node.(*quadNode)
+	if !ok {
+		return
+	}
+	shard := &nodeCache[Shard(qn.hash, cacheShards)]
+	if _, ok := shard.nodes[*qn]; ok {
+		return
+	}
+	shard.nodes[*qn] = qn
+	keep(qn.nw)
+	keep(qn.ne)
+	keep(qn.sw)
+	keep(qn.se)
+}
+
+// Returns which shard of a cache split into the given number of shards a node with the given hash goes in
+func Shard(hash uint64, shards int) int {
+	// The high bits are the most mixed up
+	return int((hash >> 32) % uint64(shards))
+}
+
 // Returns a new tree node. Caches the resulting node so that only one canonical copy of each node exists at any time.
 func QuadNode(nw, ne, sw, se Node) Node {
-	node := quadNode{nw, ne, sw, se, nw.Level() + 1}
-	cached, ok := nodeCache[node]
+	population := nw.Population() + ne.Population() + sw.Population() + se.Population()
+	node := quadNode{nw, ne, sw, se, nw.Level() + 1, population, combineHashes(nw.Level()+1, nw, ne, sw, se)}
+
+	shard := &nodeCache[Shard(node.hash, cacheShards)]
+	shard.Lock()
+	cached, ok := shard.nodes[node]
 
 	if !ok {
-		nodeCache[node] = &node
+		shard.nodes[node] = &node
+		shard.Unlock()
 		return &node
 	} else {
+		shard.Unlock()
 		return cached
 	}
 }
 
+// Returns the hash of a node at the given level with the given quadrants
+func combineHashes(level uint, nw, ne, sw, se Node) uint64 {
+	// FNV-1a over the hashes of the quadrants, followed by a finalizer to mix up the bits
+	const prime = 1099511628211
+	hash := uint64(14695981039346656037) ^ uint64(level)
+	hash = (hash ^ nw.Hash()) * prime
+	hash = (hash ^ ne.Hash()) * prime
+	hash = (hash ^ sw.Hash()) * prime
+	hash = (hash ^ se.Hash()) * prime
+	hash ^= hash >> 33
+	hash *= 0xff51afd7ed558ccd
+	hash ^= hash >> 33
+	return hash
+}
+
+func (qn *quadNode) Hash() uint64 {
+	return qn.hash
+}
+
 func (qn *quadNode) Level() uint {
 	return qn.level
 }
 
+func (qn *quadNode) Population() uint64 {
+	return qn.population
+}
+
 func outOfBound(x, y, subsectionSize int64) bool {
 	return x >= subsectionSize || x < -subsectionSize || y >= subsectionSize || y < -subsectionSize
 }
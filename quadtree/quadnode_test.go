@@ -44,6 +44,20 @@ var _ = Describe("quadNode", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(qn).To(Equal(original.(*quadNode)))
 	})
+	It("keeps the nodes it's told to when collecting garbage", func() {
+		kept, err := quad.SetValue(3, -2, true)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = quad.SetValue(-5, 7, true)
+		Expect(err).ToNot(HaveOccurred())
+
+		CollectGarbage(kept)
+		// Just the nodes along the path to the cell, and the empty nodes at each level
+		Expect(CacheSize()).To(BeNumerically("<=", 2*int(kept.Level())))
+
+		rebuilt, err := quad.SetValue(3, -2, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rebuilt).To(BeIdenticalTo(kept))
+	})
 })
 
 // Asserts a val is false.
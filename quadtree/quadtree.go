@@ -8,6 +8,12 @@ type Node interface {
 	// Returns the level of the node
 	Level() uint
 
+	// Returns the number of alive cells in the node
+	Population() uint64
+
+	// Returns a hash of the cells in the node, which is the same for equal nodes
+	Hash() uint64
+
 	// Returns a copy of the node with the given cell in the node set to that value. (0,0) is the center of the node. A cell is identified by the coordinate of its lower left corner
 	// Returns an error if the coordinate is out of bounds.
 	SetValue(x, y int64, val bool) (Node, error)
@@ -0,0 +1,163 @@
+package quadtree
+
+// Calls f with the coordinates of every alive cell in the node. As with GetValue, (0,0) is the center of the node.
+// Empty subnodes are skipped entirely, so this is proportional to the number of alive cells rather than the size of the node.
+// For nodes above level 64, only the cells with coordinates that fit in an int64 are visited.
+func ForEachAlive(node Node, f func(x, y int64)) {
+	walk(addressable(node), 0, 0, nil, f)
+}
+
+// Calls f with the coordinates of every alive cell in the node that lies in the region from min coordinates (inclusive) to max
+// coordinates (exclusive). Subnodes outside of the region are skipped, so this only looks at the part of the tree that's needed.
+func ForEachAliveIn(node Node, min_x, min_y, max_x, max_y int64, f func(x, y int64)) {
+	if min_x >= max_x || min_y >= max_y {
+		return
+	}
+	walk(addressable(node), 0, 0, &region{min_x, min_y, max_x - 1, max_y - 1}, f)
+}
+
+// A rectangle of cells, with inclusive bounds
+type region struct {
+	min_x, min_y, max_x, max_y int64
+}
+
+// Returns whether any of a node at the given level and centered at (x, y) lies in the region
+func (r *region) overlaps(level uint, x, y int64) bool {
+	if level == 0 {
+		return x >= r.min_x && x <= r.max_x && y >= r.min_y && y <= r.max_y
+	}
+	// A node at level 64 covers every cell we can address
+	if level >= 64 {
+		return true
+	}
+	half := int64(1) << (level - 1)
+	return x-half <= r.max_x && x+half-1 >= r.min_x && y-half <= r.max_y && y+half-1 >= r.min_y
+}
+
+// Calls f for every alive cell in a node centered at (x, y). If bounds is not nil, only cells in it are visited.
+func walk(node Node, x, y int64, bounds *region, f func(x, y int64)) {
+	if node.Population() == 0 {
+		return
+	}
+	if bounds != nil && !bounds.overlaps(node.Level(), x, y) {
+		return
+	}
+
+	if node.Level() == 0 {
+		f(x, y)
+		return
+	}
+
+	posOffset, negOffset := childOffsets(node.Level())
+	walk(node.NW(), x-posOffset, y-negOffset, bounds, f)
+	walk(node.NE(), x-negOffset, y-negOffset, bounds, f)
+	walk(node.SW(), x-posOffset, y-posOffset, bounds, f)
+	walk(node.SE(), x-negOffset, y-posOffset, bounds, f)
+}
+
+// Returns the offsets that take a coordinate relative to the center of a node at the given level to a coordinate relative to the
+// center of one of its quadrants. A coordinate is adjusted by the positive offset along an axis if it's on the negative
+// side of the node (i.e. west or south), and by the negative offset otherwise.
+func childOffsets(level uint) (posOffset, negOffset int64) {
+	if level == 1 {
+		// Note: level 1 is a special case because we're using integer division (i.e. 1 / 2 == 0)
+		return 1, 0
+	}
+
+	offset := int64(1) << (level - 2)
+	return offset, -offset
+}
+
+// Returns the node at level 64 centered in the given node, which holds all the cells with coordinates that fit in an int64.
+// Nodes at level 64 or below are returned as is.
+func addressable(node Node) Node {
+	for node.Level() > 64 {
+		node = QuadNode(node.NW().SE(), node.NE().SW(), node.SW().NE(), node.SE().NW())
+	}
+	return node
+}
+
+// Calls f for every non-empty block of 2^level by 2^level cells in the node that overlaps the region from min coordinates
+// (inclusive) to max coordinates (exclusive). Blocks are the subnodes at the given level, so they're aligned to multiples of 2^level.
+// f gets the coordinates of the lower left cell of the block, and the block itself.
+func ForEachBlockIn(node Node, level uint, min_x, min_y, max_x, max_y int64, f func(x, y int64, block Node)) {
+	if min_x >= max_x || min_y >= max_y {
+		return
+	}
+	walkBlocks(addressable(node), 0, 0, level, &region{min_x, min_y, max_x - 1, max_y - 1}, f)
+}
+
+// Calls f for every non-empty subnode at the given level of a node centered at (x, y)
+func walkBlocks(node Node, x, y int64, level uint, bounds *region, f func(x, y int64, block Node)) {
+	if node.Population() == 0 || !bounds.overlaps(node.Level(), x, y) {
+		return
+	}
+
+	if node.Level() <= level {
+		if node.Level() > 0 {
+			half := int64(1) << (node.Level() - 1)
+			x, y = x-half, y-half
+		}
+		f(x, y, node)
+		return
+	}
+
+	posOffset, negOffset := childOffsets(node.Level())
+	walkBlocks(node.NW(), x-posOffset, y-negOffset, level, bounds, f)
+	walkBlocks(node.NE(), x-negOffset, y-negOffset, level, bounds, f)
+	walkBlocks(node.SW(), x-posOffset, y-posOffset, level, bounds, f)
+	walkBlocks(node.SE(), x-negOffset, y-posOffset, level, bounds, f)
+}
+
+// Returns the smallest rectangle containing all the alive cells in the node, with inclusive bounds,
+// or false if there aren't any alive cells. Like ForEachAlive, only cells with int64 coordinates are considered.
+func BoundingBox(node Node) (min_x, min_y, max_x, max_y int64, ok bool) {
+	node = addressable(node)
+	if node.Population() == 0 {
+		return 0, 0, 0, 0, false
+	}
+
+	west := func(n Node) (Node, Node) { return n.NW(), n.SW() }
+	east := func(n Node) (Node, Node) { return n.NE(), n.SE() }
+	south := func(n Node) (Node, Node) { return n.SW(), n.SE() }
+	north := func(n Node) (Node, Node) { return n.NW(), n.NE() }
+
+	return extent(node, 0, west, east, false), extent(node, 0, south, north, false),
+		extent(node, 0, west, east, true), extent(node, 0, south, north, true), true
+}
+
+/*
+Returns the smallest (or largest, if max is set) coordinate along one axis of an alive cell in a non-empty node centered at c on that axis.
+neg and pos return the two quadrants on the negative and positive side of the axis.
+Only the quadrants on the side we're looking for are searched, unless they're both empty.
+*/
+func extent(node Node, c int64, neg, pos func(Node) (Node, Node), max bool) int64 {
+	if node.Level() == 0 {
+		return c
+	}
+
+	posOffset, negOffset := childOffsets(node.Level())
+	near, nearC, far, farC := neg, c-posOffset, pos, c-negOffset
+	if max {
+		near, nearC, far, farC = pos, c-negOffset, neg, c-posOffset
+	}
+
+	a, b := near(node)
+	if a.Population() == 0 && b.Population() == 0 {
+		a, b = far(node)
+		nearC = farC
+	}
+
+	var best int64
+	found := false
+	for _, child := range []Node{a, b} {
+		if child.Population() == 0 {
+			continue
+		}
+		val := extent(child, nearC, neg, pos, max)
+		if !found || (max && val > best) || (!max && val < best) {
+			best, found = val, true
+		}
+	}
+	return best
+}
@@ -0,0 +1,77 @@
+package quadtree
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ForEachAlive", func() {
+	var cells [][2]int64
+	BeforeEach(func() {
+		cells = [][2]int64{{0, 0}, {-1, 0}, {-150, -10}, {50, -15}, {-3, 7}}
+	})
+
+	collect := func(node Node) [][2]int64 {
+		visited := [][2]int64{}
+		ForEachAlive(node, func(x, y int64) {
+			visited = append(visited, [2]int64{x, y})
+		})
+		return visited
+	}
+
+	It("visits nothing on an empty tree", func() {
+		Expect(collect(EmptyTree(10))).To(BeEmpty())
+	})
+	It("visits every alive cell in a small tree", func() {
+		tree := EmptyTree(10)
+		for _, cell := range cells {
+			tree, _ = tree.SetValue(cell[0], cell[1], true)
+		}
+		Expect(tree.Population()).To(Equal(uint64(len(cells))))
+		Expect(collect(tree)).To(ConsistOf(cells))
+	})
+	It("visits cells at the edges of a full size board", func() {
+		tree := EmptyTree(66)
+		edges := [][2]int64{{-1 << 63, 1<<63 - 1}, {1<<63 - 1, -1 << 63}, {0, 0}}
+		for _, cell := range edges {
+			tree, _ = tree.SetValue(cell[0], cell[1], true)
+		}
+		Expect(collect(tree)).To(ConsistOf(edges))
+	})
+
+	It("only visits the cells in a region", func() {
+		tree := EmptyTree(66)
+		for _, cell := range cells {
+			tree, _ = tree.SetValue(cell[0], cell[1], true)
+		}
+		visited := [][2]int64{}
+		ForEachAliveIn(tree, -5, -15, 51, 1, func(x, y int64) {
+			visited = append(visited, [2]int64{x, y})
+		})
+		Expect(visited).To(ConsistOf([][2]int64{{0, 0}, {-1, 0}, {50, -15}}))
+	})
+
+	It("finds the bounding box of the alive cells", func() {
+		tree := EmptyTree(66)
+		_, _, _, _, ok := BoundingBox(tree)
+		Expect(ok).To(BeFalse())
+
+		for _, cell := range cells {
+			tree, _ = tree.SetValue(cell[0], cell[1], true)
+		}
+		min_x, min_y, max_x, max_y, ok := BoundingBox(tree)
+		Expect(ok).To(BeTrue())
+		Expect([]int64{min_x, min_y, max_x, max_y}).To(Equal([]int64{-150, -15, 50, 7}))
+	})
+	It("visits the blocks holding alive cells", func() {
+		tree := EmptyTree(66)
+		for _, cell := range cells {
+			tree, _ = tree.SetValue(cell[0], cell[1], true)
+		}
+		blocks := map[[2]int64]uint64{}
+		ForEachBlockIn(tree, 2, -8, -16, 8, 8, func(x, y int64, block Node) {
+			blocks[[2]int64{x, y}] = block.Population()
+		})
+		Expect(blocks).To(Equal(map[[2]int64]uint64{{0, 0}: 1, {-4, 0}: 1, {-4, 4}: 1}))
+	})
+})
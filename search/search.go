@@ -0,0 +1,247 @@
+/*
+search runs lots of random soups until they settle, and counts the objects left over, looking for rare ones
+*/
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/analysis"
+	"github.com/mitchellgordon95/ConwaysGOL/hashlife"
+	qt "github.com/mitchellgordon95/ConwaysGOL/quadtree"
+	"github.com/mitchellgordon95/ConwaysGOL/soup"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+)
+
+// What to search
+type Options struct {
+	// The soups to run. Soup number i gets the seed Soup.Seed + i.
+	Soup soup.Soup
+	// The number of soups to run in total, counting the ones run before resuming
+	Soups uint64
+	// The number of soups to run at the same time
+	Workers int
+	// The most generations to run a soup for before giving up on it settling
+	Generations uint64
+	// How far apart cells can be and still count as the same object
+	Distance int64
+	// How many soups to run between saves
+	SaveEvery uint64
+	// The number of nodes the cache can hold before it gets cleared, or 0 for the default
+	CacheSize uint64
+}
+
+// A rare object, and the soup it came from
+type Find struct {
+	Seed   int64
+	Object string
+	Kind   string
+	Period uint64
+}
+
+// The results of a search so far. They can be saved, and loaded again to pick up where the search left off.
+type Results struct {
+	// The soups being searched
+	Soup soup.Soup
+	// The number of soups that have been run. Since soups are counted in order, these are soups 0 up to Soups-1.
+	Soups uint64
+	// The number of soups that didn't settle in time
+	Unsettled uint64
+	// The number of each object seen, by name
+	Counts map[string]uint64
+	// The rare objects found
+	Finds []Find
+}
+
+// Returns results for a search that hasn't started yet
+func NewResults(s soup.Soup) *Results {
+	return &Results{Soup: s, Counts: map[string]uint64{}}
+}
+
+// The number of nodes the cache can hold before it gets cleared, unless the options say otherwise
+const defaultCacheSize = 1 << 20
+
+// What happened to a single soup
+type outcome struct {
+	index   uint64
+	settled bool
+	census  []analysis.CensusEntry
+}
+
+/*
+Runs soups until the results have as many as the options ask for, or until stop is closed. Soups are run by several workers at
+once, but their results are added in order, so the results always cover a whole number of soups from the start.
+save is called with the results every so often, and at the end.
+*/
+func Run(results *Results, opts Options, stop <-chan struct{}, save func(*Results) error) error {
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+	if opts.CacheSize == 0 {
+		opts.CacheSize = defaultCacheSize
+	}
+
+	indexes := make(chan uint64)
+	outcomes := make(chan outcome)
+
+	// Hand out the soups to run, until there aren't any left or we're told to stop
+	go func() {
+		defer close(indexes)
+		for i := results.Soups; i < opts.Soups; i++ {
+			select {
+			case indexes <- i:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	// Held for reading while a soup runs, and for writing while the caches are cleared, so a clear never lands in the
+	// middle of a soup
+	var running sync.RWMutex
+	var workers sync.WaitGroup
+	for w := 0; w < opts.Workers; w++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for i := range indexes {
+				running.RLock()
+				out := runSoup(results.Soup, i, opts)
+				running.RUnlock()
+				outcomes <- out
+			}
+		}()
+	}
+	go func() {
+		workers.Wait()
+		close(outcomes)
+	}()
+
+	// Soups can finish out of order, so hold on to them until all the ones before them are done
+	pending := map[uint64]outcome{}
+	var sinceSave uint64
+	for out := range outcomes {
+		pending[out.index] = out
+		for {
+			next, ok := pending[results.Soups]
+			if !ok {
+				break
+			}
+			delete(pending, results.Soups)
+			results.add(next)
+			sinceSave++
+		}
+
+		// Nothing from one soup is needed for the next, so rather than let the caches grow forever, throw them away
+		// when they get big. Settling a soup compares its boards from one check to the next, which only works if they
+		// were all made with the same cache, so wait for the soups being run to finish first.
+		if uint64(qt.CacheSize()) > opts.CacheSize {
+			running.Lock()
+			hashlife.ClearCaches()
+			running.Unlock()
+		}
+
+		if opts.SaveEvery > 0 && sinceSave >= opts.SaveEvery {
+			if err := save(results); err != nil {
+				return err
+			}
+			sinceSave = 0
+		}
+	}
+
+	return save(results)
+}
+
+// Runs a single soup until it settles, and takes a census of what's left
+func runSoup(s soup.Soup, index uint64, opts Options) outcome {
+	s.Seed += int64(index)
+	out := outcome{index: index}
+
+	board, err := s.Place(hashlife.NewHashLifeBoard(), 0, 0)
+	if err != nil {
+		return out
+	}
+	board, _, out.settled = analysis.Settle(board, opts.Generations)
+	if out.settled {
+		out.census = analysis.Census(board, opts.Distance)
+	}
+	return out
+}
+
+// Adds what happened to a soup to the results
+func (r *Results) add(out outcome) {
+	r.Soups++
+	if !out.settled {
+		r.Unsettled++
+		return
+	}
+
+	for _, entry := range out.census {
+		r.Counts[entry.Name] += uint64(entry.Count)
+		if !analysis.IsCommon(entry.Object) {
+			find := Find{Seed: r.Soup.Seed + int64(out.index), Object: entry.Name, Kind: "unsettled"}
+			if entry.Settled {
+				find.Kind, find.Period = entry.Cycle.Kind(), entry.Cycle.Period
+			}
+			r.Finds = append(r.Finds, find)
+		}
+	}
+}
+
+// Reads results saved by Save
+func Load(filename string) (*Results, error) {
+	file, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	results := NewResults(soup.Soup{})
+	if err := json.Unmarshal(file, results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Saves the results as json. The file is replaced all at once, so it's never left half written if the search is interrupted.
+func (r *Results) Save(filename string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	temp := filename + ".tmp"
+	if err := ioutil.WriteFile(temp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(temp, filename)
+}
+
+// Returns the lines of a table of the objects seen so far, most common first, followed by the rare finds
+func (r *Results) Table() []string {
+	names := make([]string, 0, len(r.Counts))
+	for name := range r.Counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if r.Counts[names[i]] != r.Counts[names[j]] {
+			return r.Counts[names[i]] > r.Counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	lines := []string{fmt.Sprintf("%10s  %s", "COUNT", "OBJECT")}
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("%10d  %s", r.Counts[name], name))
+	}
+
+	if len(r.Finds) > 0 {
+		lines = append(lines, "", fmt.Sprintf("%20s  %s", "SEED", "RARE FIND"))
+		for _, find := range r.Finds {
+			lines = append(lines, fmt.Sprintf("%20d  %s", find.Seed, find.Object))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,91 @@
+package search_test
+
+import (
+	. "github.com/mitchellgordon95/ConwaysGOL/search"
+	"github.com/mitchellgordon95/ConwaysGOL/soup"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+var _ = Describe("Run", func() {
+	var opts Options
+	BeforeEach(func() {
+		opts = Options{
+			Soup:        soup.Soup{Width: 8, Height: 8, Density: 0.5, Symmetry: soup.C1, Seed: 100},
+			Soups:       12,
+			Workers:     3,
+			Generations: 20000,
+			Distance:    1,
+			SaveEvery:   4,
+		}
+	})
+
+	run := func(results *Results, opts Options, save func(*Results) error) *Results {
+		if save == nil {
+			save = func(*Results) error { return nil }
+		}
+		Expect(Run(results, opts, nil, save)).To(Succeed())
+		return results
+	}
+
+	It("runs every soup", func() {
+		results := run(NewResults(opts.Soup), opts, nil)
+		Expect(results.Soups).To(Equal(uint64(12)))
+
+		var objects uint64
+		for _, count := range results.Counts {
+			objects += count
+		}
+		Expect(objects).To(BeNumerically(">", 0))
+	})
+	It("gets the same results however many workers there are", func() {
+		parallel := run(NewResults(opts.Soup), opts, nil)
+		opts.Workers = 1
+		Expect(run(NewResults(opts.Soup), opts, nil)).To(Equal(parallel))
+	})
+	It("gets the same results however often the caches are cleared", func() {
+		whole := run(NewResults(opts.Soup), opts, nil)
+		opts.CacheSize = 1
+		Expect(run(NewResults(opts.Soup), opts, nil)).To(Equal(whole))
+	})
+	It("saves every so often, covering whole numbers of soups", func() {
+		var saved []uint64
+		run(NewResults(opts.Soup), opts, func(results *Results) error {
+			saved = append(saved, results.Soups)
+			return nil
+		})
+		// Soups can finish out of order, so a save can come a little late, but never early
+		Expect(saved[len(saved)-1]).To(Equal(uint64(12)))
+		for i := 1; i < len(saved)-1; i++ {
+			Expect(saved[i] - saved[i-1]).To(BeNumerically(">=", 4))
+		}
+	})
+	It("stops when it's told to", func() {
+		stop := make(chan struct{})
+		close(stop)
+		results := NewResults(opts.Soup)
+		Expect(Run(results, opts, stop, func(*Results) error { return nil })).To(Succeed())
+		Expect(results.Soups).To(BeNumerically("<", 12))
+	})
+
+	It("picks up where it left off", func() {
+		dir, err := ioutil.TempDir("", "search")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+		filename := filepath.Join(dir, "results.json")
+
+		whole := run(NewResults(opts.Soup), opts, nil)
+
+		half := opts
+		half.Soups = 5
+		Expect(run(NewResults(opts.Soup), half, nil).Save(filename)).To(Succeed())
+		resumed, err := Load(filename)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resumed.Soups).To(Equal(uint64(5)))
+
+		Expect(run(resumed, opts, nil)).To(Equal(whole))
+	})
+})
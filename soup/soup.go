@@ -0,0 +1,140 @@
+/*
+soup generates random patterns, or soups, for testing and for searching for interesting objects
+*/
+package soup
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/pattern"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// The ways a soup can be symmetric, named the same way as in other soup searchers
+type Symmetry string
+
+const (
+	// No symmetry at all
+	C1 Symmetry = "C1"
+	// The same when turned half way around
+	C2 Symmetry = "C2"
+	// The same when turned a quarter of the way around. Needs a square soup.
+	C4 Symmetry = "C4"
+	// The same when mirrored left to right
+	D2 Symmetry = "D2"
+	// The same when mirrored left to right or top to bottom
+	D4 Symmetry = "D4"
+	// The same when turned or mirrored in any way. Needs a square soup.
+	D8 Symmetry = "D8"
+)
+
+// The orientations that leave a soup with each symmetry the same
+var symmetries = map[Symmetry][]pattern.Orientation{
+	C1: {{}},
+	C2: {{}, {Turns: 2}},
+	C4: {{}, {Turns: 1}, {Turns: 2}, {Turns: 3}},
+	D2: {{}, {Flip: true}},
+	D4: {{}, {Flip: true}, {Turns: 2}, {Turns: 2, Flip: true}},
+	D8: pattern.Orientations,
+}
+
+// Parses the name of a symmetry, like C1 or D8
+func ParseSymmetry(name string) (Symmetry, error) {
+	symmetry := Symmetry(strings.ToUpper(name))
+	if _, ok := symmetries[symmetry]; !ok {
+		return "", errors.New("Unknown symmetry " + name + ", expected one of C1, C2, C4, D2, D4 or D8")
+	}
+	return symmetry, nil
+}
+
+// The most cells a soup can have, to keep from running out of memory
+const maxCells = 1 << 26
+
+// A random pattern that fills a rectangle
+type Soup struct {
+	Width, Height int64
+	// The chance of each cell being alive, from 0 to 1
+	Density  float64
+	Symmetry Symmetry
+	// The same seed always gives the same soup
+	Seed int64
+}
+
+// Returns a seed that's different every time
+func NewSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// Returns the alive cells of the soup, in a rectangle with its lower left corner at (0,0)
+func (s Soup) Cells() ([]common.Cell, error) {
+	orientations, ok := symmetries[s.Symmetry]
+	if !ok {
+		return nil, errors.New("Unknown symmetry " + string(s.Symmetry))
+	}
+	if s.Width < 1 || s.Height < 1 || s.Width > maxCells/s.Height {
+		return nil, errors.New("Invalid size for a soup")
+	}
+	if s.Density < 0 || s.Density > 1 {
+		return nil, errors.New("The density should be between 0 and 1")
+	}
+	if (s.Symmetry == C4 || s.Symmetry == D8) && s.Width != s.Height {
+		return nil, fmt.Errorf("A soup with %s symmetry has to be square", s.Symmetry)
+	}
+
+	random := rand.New(rand.NewSource(s.Seed))
+	alive := make([]bool, s.Width*s.Height)
+	var cells []common.Cell
+
+	for y := int64(0); y < s.Height; y++ {
+		for x := int64(0); x < s.Width; x++ {
+			// Cells that the symmetry maps onto each other are either all alive or all dead. The first one we come across
+			// gets picked at random, and the rest are copied from it.
+			first := y*s.Width + x
+			for _, o := range orientations {
+				ox, oy := s.orient(o, x, y)
+				if i := oy*s.Width + ox; i < first {
+					first = i
+				}
+			}
+
+			i := y*s.Width + x
+			if first == i {
+				alive[i] = random.Float64() < s.Density
+			} else {
+				alive[i] = alive[first]
+			}
+			if alive[i] {
+				cells = append(cells, common.Cell{X: x, Y: y})
+			}
+		}
+	}
+
+	return cells, nil
+}
+
+// Returns where an orientation about the center of the soup takes a cell
+func (s Soup) orient(o pattern.Orientation, x, y int64) (int64, int64) {
+	// Double the coordinates so the center of the soup is on a whole number, even when the soup is an even size
+	x, y = o.Apply(2*x-(s.Width-1), 2*y-(s.Height-1))
+	return (x + s.Width - 1) / 2, (y + s.Height - 1) / 2
+}
+
+// Returns a copy of the board with the soup added to it, with the lower left corner of the soup at (x, y)
+func (s Soup) Place(board common.GolBoard, x, y int64) (common.GolBoard, error) {
+	cells, err := s.Cells()
+	if err != nil {
+		return nil, err
+	}
+	for i := range cells {
+		cells[i].X += x
+		cells[i].Y += y
+	}
+	return board.AddCells(cells), nil
+}
+
+func (s Soup) String() string {
+	return fmt.Sprintf("%dx%d %s soup with density %g and seed %d", s.Width, s.Height, s.Symmetry, s.Density, s.Seed)
+}
@@ -0,0 +1,13 @@
+package soup_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSoup(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Soup Suite")
+}
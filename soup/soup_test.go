@@ -0,0 +1,96 @@
+package soup_test
+
+import (
+	"github.com/mitchellgordon95/ConwaysGOL/common"
+	"github.com/mitchellgordon95/ConwaysGOL/hashlife"
+	"github.com/mitchellgordon95/ConwaysGOL/pattern"
+	. "github.com/mitchellgordon95/ConwaysGOL/soup"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Soup", func() {
+	var s Soup
+	BeforeEach(func() {
+		s = Soup{Width: 16, Height: 16, Density: 0.5, Symmetry: C1, Seed: 42}
+	})
+
+	cells := func(s Soup) []common.Cell {
+		cells, err := s.Cells()
+		Expect(err).NotTo(HaveOccurred())
+		return cells
+	}
+
+	It("gives the same soup for the same seed", func() {
+		Expect(cells(s)).To(Equal(cells(s)))
+		other := s
+		other.Seed++
+		Expect(cells(s)).NotTo(Equal(cells(other)))
+	})
+	It("fills the rectangle", func() {
+		for _, cell := range cells(s) {
+			Expect(cell.X).To(BeNumerically(">=", 0))
+			Expect(cell.X).To(BeNumerically("<", 16))
+			Expect(cell.Y).To(BeNumerically(">=", 0))
+			Expect(cell.Y).To(BeNumerically("<", 16))
+		}
+	})
+	It("has about the right density", func() {
+		s.Width, s.Height, s.Density = 100, 100, 0.25
+		Expect(len(cells(s))).To(BeNumerically("~", 2500, 250))
+		s.Density = 0
+		Expect(cells(s)).To(BeEmpty())
+		s.Density = 1
+		Expect(cells(s)).To(HaveLen(10000))
+	})
+
+	It("is symmetric", func() {
+		symmetric := map[Symmetry][]pattern.Orientation{
+			C2: {{Turns: 2}},
+			C4: {{Turns: 1}},
+			D2: {{Flip: true}},
+			D4: {{Flip: true}, {Turns: 2, Flip: true}},
+			D8: pattern.Orientations,
+		}
+		for symmetry, orientations := range symmetric {
+			for _, size := range [][2]int64{{16, 16}, {15, 15}, {16, 10}} {
+				s.Symmetry, s.Width, s.Height = symmetry, size[0], size[1]
+				if (symmetry == C4 || symmetry == D8) && size[0] != size[1] {
+					_, err := s.Cells()
+					Expect(err).To(HaveOccurred())
+					continue
+				}
+				p, _, _ := pattern.FromCells(cells(s))
+				for _, o := range orientations {
+					Expect(p.Orient(o).Equal(p)).To(BeTrue(), string(symmetry))
+				}
+			}
+		}
+	})
+
+	It("places the soup on the board", func() {
+		board, err := s.Place(hashlife.NewHashLifeBoard(), -8, -8)
+		Expect(err).NotTo(HaveOccurred())
+		placed, x, y := pattern.FromBoard(board)
+		expected, _, _ := pattern.FromCells(cells(s))
+		Expect(placed.Equal(expected)).To(BeTrue())
+		min_x, min_y, _, _, _ := board.BoundingBox()
+		Expect([]int64{x, y}).To(Equal([]int64{min_x, min_y}))
+	})
+	It("rejects bad soups", func() {
+		s.Density = 2
+		_, err := s.Cells()
+		Expect(err).To(HaveOccurred())
+		s.Density, s.Width = 0.5, 0
+		_, err = s.Cells()
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("ParseSymmetry", func() {
+	It("parses names in any case", func() {
+		Expect(ParseSymmetry("d4")).To(Equal(D4))
+		_, err := ParseSymmetry("D3")
+		Expect(err).To(HaveOccurred())
+	})
+})